@@ -0,0 +1,314 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// StateMutability describes whether a function reads or changes state
+type StateMutability string
+
+const (
+	StatePure       StateMutability = "pure"
+	StateView       StateMutability = "view"
+	StateNonPayable StateMutability = "nonpayable"
+	StatePayable    StateMutability = "payable"
+)
+
+// ABI is a parsed contract ABI definition
+type ABI struct {
+	Constructor *ABIMethod
+	Fallback    *ABIMethod
+	Receive     *ABIMethod
+	Functions   []*ABIMethod
+	Events      []*ABIEvent
+	Errors      []*ABIError
+}
+
+// ABIArgument is an input or output of a function, event or error
+type ABIArgument struct {
+	Name string `json:"name"`
+
+	// Solidity type, such as "uint256", "bytes32[]" or "tuple"
+	Type string `json:"type"`
+
+	// Type as declared in the source, such as "contract IERC20"
+	InternalType string `json:"internalType"`
+
+	// Fields of a tuple type
+	Components []ABIArgument `json:"components"`
+
+	// Whether an event argument is stored in the log topics
+	Indexed bool `json:"indexed"`
+}
+
+// ABIMethod is a function, constructor, fallback or receive function
+type ABIMethod struct {
+	Name string
+
+	// One of "function", "constructor", "fallback" or "receive"
+	Type string
+
+	Inputs  []ABIArgument
+	Outputs []ABIArgument
+
+	StateMutability StateMutability
+}
+
+// ABIEvent is an event a contract can emit
+type ABIEvent struct {
+	Name      string
+	Inputs    []ABIArgument
+	Anonymous bool
+}
+
+// ABIError is a custom error a contract can revert with
+type ABIError struct {
+	Name   string
+	Inputs []ABIArgument
+}
+
+// Unparsed ABI entry
+type abiEntry struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name"`
+	Inputs          []ABIArgument `json:"inputs"`
+	Outputs         []ABIArgument `json:"outputs"`
+	StateMutability string        `json:"stateMutability"`
+	Anonymous       bool          `json:"anonymous"`
+
+	// Replaced by stateMutability in Solidity 0.5
+	Constant bool `json:"constant"`
+	Payable  bool `json:"payable"`
+}
+
+// ParseABI parses a JSON ABI definition, as returned by ContractABI
+func ParseABI(data []byte) (*ABI, error) {
+	// The API returns the definition as a JSON encoded string
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		data = []byte(s)
+	}
+
+	var entries []abiEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.New("Could not parse ABI: " + err.Error())
+	}
+
+	abi := &ABI{}
+	for _, e := range entries {
+		switch e.Type {
+		case "", "function", "constructor", "fallback", "receive":
+			method := &ABIMethod{
+				Name:            e.Name,
+				Type:            e.Type,
+				Inputs:          e.Inputs,
+				Outputs:         e.Outputs,
+				StateMutability: parseStateMutability(&e),
+			}
+			switch e.Type {
+			case "constructor":
+				abi.Constructor = method
+			case "fallback":
+				abi.Fallback = method
+			case "receive":
+				abi.Receive = method
+			default:
+				method.Type = "function"
+				abi.Functions = append(abi.Functions, method)
+			}
+		case "event":
+			abi.Events = append(abi.Events, &ABIEvent{
+				Name:      e.Name,
+				Inputs:    e.Inputs,
+				Anonymous: e.Anonymous,
+			})
+		case "error":
+			abi.Errors = append(abi.Errors, &ABIError{
+				Name:   e.Name,
+				Inputs: e.Inputs,
+			})
+		default:
+			return nil, fmt.Errorf("Unsupported ABI entry type: %s", e.Type)
+		}
+	}
+	return abi, nil
+}
+
+// Derives the state mutability of ABIs predating the stateMutability field
+func parseStateMutability(e *abiEntry) StateMutability {
+	switch {
+	case e.StateMutability != "":
+		return StateMutability(e.StateMutability)
+	case e.Payable:
+		return StatePayable
+	case e.Constant:
+		return StateView
+	}
+	return StateNonPayable
+}
+
+// Function returns the first function with the given name, or nil
+func (a *ABI) Function(name string) *ABIMethod {
+	for _, m := range a.Functions {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// FunctionBySignature returns the function with the given signature, such as
+// "safeTransferFrom(address,address,uint256)", to pick one of overloaded
+// functions. It returns nil if there is none or the signature is invalid
+func (a *ABI) FunctionBySignature(sig string) *ABIMethod {
+	name, args, err := parseSignature(strings.TrimPrefix(strings.TrimSpace(sig), "function "))
+	if err != nil {
+		return nil
+	}
+	sig = signature(name, args)
+	for _, m := range a.Functions {
+		if m.Signature() == sig {
+			return m
+		}
+	}
+	return nil
+}
+
+// FunctionBySelector returns the function with the given 4-byte selector,
+// such as "0xa9059cbb", or nil
+func (a *ABI) FunctionBySelector(selector string) *ABIMethod {
+	selector = strings.ToLower(selector)
+	for _, m := range a.Functions {
+		if m.Selector() == selector {
+			return m
+		}
+	}
+	return nil
+}
+
+// Event returns the first event with the given name, or nil
+func (a *ABI) Event(name string) *ABIEvent {
+	for _, e := range a.Events {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// EventByTopic returns the event with the given topic0 hash, or nil.
+// Anonymous events have no topic and are never matched
+func (a *ABI) EventByTopic(topic string) *ABIEvent {
+	topic = strings.ToLower(topic)
+	for _, e := range a.Events {
+		if !e.Anonymous && e.Topic() == topic {
+			return e
+		}
+	}
+	return nil
+}
+
+// Error returns the first custom error with the given name, or nil
+func (a *ABI) Error(name string) *ABIError {
+	for _, e := range a.Errors {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// CanonicalType returns the type as used in signatures, with tuples
+// expanded to their component types, such as "(address,uint256)[]"
+func (a *ABIArgument) CanonicalType() string {
+	if !strings.HasPrefix(a.Type, "tuple") {
+		return a.Type
+	}
+	types := make([]string, len(a.Components))
+	for i := range a.Components {
+		types[i] = a.Components[i].CanonicalType()
+	}
+	return "(" + strings.Join(types, ",") + ")" + strings.TrimPrefix(a.Type, "tuple")
+}
+
+// Builds a signature such as "transfer(address,uint256)"
+func signature(name string, args []ABIArgument) string {
+	types := make([]string, len(args))
+	for i := range args {
+		types[i] = args[i].CanonicalType()
+	}
+	return name + "(" + strings.Join(types, ",") + ")"
+}
+
+// Signature returns the canonical signature of the method, such as
+// "transfer(address,uint256)"
+func (m *ABIMethod) Signature() string {
+	return signature(m.Name, m.Inputs)
+}
+
+// Selector returns the 4-byte selector identifying calls to the method, hex
+// encoded with 0x prefix
+func (m *ABIMethod) Selector() string {
+	return "0x" + hex.EncodeToString(keccak256([]byte(m.Signature()))[:4])
+}
+
+// Signature returns the canonical signature of the event, such as
+// "Transfer(address,address,uint256)"
+func (e *ABIEvent) Signature() string {
+	return signature(e.Name, e.Inputs)
+}
+
+// Topic returns the topic0 hash identifying logs of the event, hex encoded
+// with 0x prefix
+func (e *ABIEvent) Topic() string {
+	return "0x" + hex.EncodeToString(keccak256([]byte(e.Signature())))
+}
+
+// Signature returns the canonical signature of the error, such as
+// "InsufficientBalance(uint256,uint256)"
+func (e *ABIError) Signature() string {
+	return signature(e.Name, e.Inputs)
+}
+
+// Selector returns the 4-byte selector identifying the error in revert data,
+// hex encoded with 0x prefix
+func (e *ABIError) Selector() string {
+	return "0x" + hex.EncodeToString(keccak256([]byte(e.Signature()))[:4])
+}
+
+// Returns the Keccak-256 hash used by Ethereum, which differs from SHA3-256
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (c *Client) contractABIParsed(ctx context.Context, addr string) (*ABI, error) {
+	data, err := c.contractABI(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return ParseABI(data)
+}
+
+// ContractABIParsed returns the parsed ABI definition for a smart contract at
+// the given address
+func (c *Client) ContractABIParsed(addr string) (*ABI, error) {
+	return c.contractABIParsed(context.Background(), addr)
+}
+
+// ContractABIParsedContext returns the parsed ABI definition for a smart
+// contract at the given address with a custom context
+func (c *Client) ContractABIParsedContext(ctx context.Context, addr string) (*ABI, error) {
+	return c.contractABIParsed(ctx, addr)
+}
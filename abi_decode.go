@@ -0,0 +1,335 @@
+package etherscan
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Size of a single ABI encoded word
+const abiWordSize = 32
+
+// abiType is a parsed Solidity type
+type abiType struct {
+	// Base kind: "uint", "int", "address", "bool", "bytes", "fixedbytes",
+	// "string", "tuple", "slice" or "array"
+	kind string
+
+	// Bit size of integers, or byte size of fixed bytes
+	size int
+
+	// Element type of slices and arrays, and length of arrays
+	elem   *abiType
+	length int
+
+	// Fields of tuples
+	fields []*abiType
+}
+
+// Parses the type of an argument, including tuple components
+func parseABIType(arg *ABIArgument) (*abiType, error) {
+	return parseABITypeString(arg.Type, arg.Components)
+}
+
+func parseABITypeString(typ string, components []ABIArgument) (*abiType, error) {
+	// Arrays are parsed from the outermost dimension, the last one
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndex(typ, "[")
+		if i < 0 {
+			return nil, fmt.Errorf("Invalid ABI type: %s", typ)
+		}
+		elem, err := parseABITypeString(typ[:i], components)
+		if err != nil {
+			return nil, err
+		}
+		dim := typ[i+1 : len(typ)-1]
+		if dim == "" {
+			return &abiType{kind: "slice", elem: elem}, nil
+		}
+		length, err := strconv.Atoi(dim)
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("Invalid ABI type: %s", typ)
+		}
+		return &abiType{kind: "array", elem: elem, length: length}, nil
+	}
+
+	switch {
+	case typ == "address", typ == "bool", typ == "string", typ == "bytes":
+		return &abiType{kind: typ}, nil
+	case typ == "tuple":
+		t := &abiType{kind: "tuple", fields: make([]*abiType, len(components))}
+		for i := range components {
+			field, err := parseABIType(&components[i])
+			if err != nil {
+				return nil, err
+			}
+			t.fields[i] = field
+		}
+		return t, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		kind := "int"
+		if strings.HasPrefix(typ, "uint") {
+			kind = "uint"
+		}
+		size := 256
+		if bits := strings.TrimPrefix(typ, kind); bits != "" {
+			n, err := strconv.Atoi(bits)
+			if err != nil || n <= 0 || n > 256 || n%8 != 0 {
+				return nil, fmt.Errorf("Invalid ABI type: %s", typ)
+			}
+			size = n
+		}
+		return &abiType{kind: kind, size: size}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || n <= 0 || n > 32 {
+			return nil, fmt.Errorf("Invalid ABI type: %s", typ)
+		}
+		return &abiType{kind: "fixedbytes", size: n}, nil
+	}
+	return nil, fmt.Errorf("Unsupported ABI type: %s", typ)
+}
+
+// Reports whether values of the type are encoded in place rather than at an
+// offset
+func (t *abiType) static() bool {
+	switch t.kind {
+	case "bytes", "string", "slice":
+		return false
+	case "array":
+		return t.elem.static()
+	case "tuple":
+		for _, f := range t.fields {
+			if !f.static() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Number of bytes the type takes in the head of its enclosing tuple
+func (t *abiType) headSize() int {
+	if !t.static() {
+		return abiWordSize
+	}
+	switch t.kind {
+	case "array":
+		return t.length * t.elem.headSize()
+	case "tuple":
+		size := 0
+		for _, f := range t.fields {
+			size += f.headSize()
+		}
+		return size
+	}
+	return abiWordSize
+}
+
+// Reads the word at the given offset
+func abiWord(data []byte, offset int) ([]byte, error) {
+	if offset < 0 || offset+abiWordSize > len(data) {
+		return nil, errors.New("ABI data too short")
+	}
+	return data[offset : offset+abiWordSize], nil
+}
+
+// Reads a word used as an offset or length
+func abiInt(data []byte, offset int) (int, error) {
+	word, err := abiWord(data, offset)
+	if err != nil {
+		return 0, err
+	}
+	n := new(big.Int).SetBytes(word)
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, errors.New("ABI offset out of range")
+	}
+	return int(n.Int64()), nil
+}
+
+// Decodes a value of type t at the given offset. Integers are decoded as
+// *big.Int, addresses as lowercase hex strings, fixed and dynamic bytes as
+// []byte, arrays and tuples as []interface{}
+func decodeABIValue(t *abiType, data []byte, offset int) (interface{}, error) {
+	switch t.kind {
+	case "uint", "int":
+		word, err := abiWord(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).SetBytes(word)
+		if t.kind == "int" && word[0]&0x80 != 0 {
+			// Negative two's complement
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return n, nil
+	case "address":
+		word, err := abiWord(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(word[12:]), nil
+	case "bool":
+		word, err := abiWord(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		return word[abiWordSize-1] == 1, nil
+	case "fixedbytes":
+		word, err := abiWord(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, word[:t.size]...), nil
+	case "bytes", "string":
+		start, err := abiInt(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		length, err := abiInt(data, start)
+		if err != nil {
+			return nil, err
+		}
+		start += abiWordSize
+		if start+length > len(data) {
+			return nil, errors.New("ABI data too short")
+		}
+		if t.kind == "string" {
+			return string(data[start : start+length]), nil
+		}
+		return append([]byte{}, data[start:start+length]...), nil
+	case "slice":
+		start, err := abiInt(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		length, err := abiInt(data, start)
+		if err != nil {
+			return nil, err
+		}
+		elems := make([]*abiType, length)
+		for i := range elems {
+			elems[i] = t.elem
+		}
+		return decodeABISequence(elems, data[start+abiWordSize:])
+	case "array", "tuple":
+		elems := t.fields
+		if t.kind == "array" {
+			elems = make([]*abiType, t.length)
+			for i := range elems {
+				elems[i] = t.elem
+			}
+		}
+		if t.static() {
+			return decodeABISequence(elems, data[offset:])
+		}
+		start, err := abiInt(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		return decodeABISequence(elems, data[start:])
+	}
+	return nil, fmt.Errorf("Unsupported ABI type: %s", t.kind)
+}
+
+// Decodes consecutive values, such as tuple fields or arguments. Offsets of
+// dynamic values are relative to the start of data
+func decodeABISequence(types []*abiType, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	offset := 0
+	for i, t := range types {
+		v, err := decodeABIValue(t, data, offset)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+		offset += t.headSize()
+	}
+	return values, nil
+}
+
+// Decodes ABI encoded arguments into Go values
+func decodeABIArguments(args []ABIArgument, data []byte) ([]interface{}, error) {
+	types := make([]*abiType, len(args))
+	for i := range args {
+		t, err := parseABIType(&args[i])
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return decodeABISequence(types, data)
+}
+
+// DecodedArgument is a decoded argument of a function call or event
+type DecodedArgument struct {
+	Name string
+	// Canonical Solidity type, such as "uint256"
+	Type  string
+	Value interface{}
+}
+
+// DecodedInput is the function call made by a transaction
+type DecodedInput struct {
+	Method *ABIMethod
+	Args   []DecodedArgument
+}
+
+// Arg returns the value of the argument with the given name
+func (d *DecodedInput) Arg(name string) (interface{}, bool) {
+	for _, a := range d.Args {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Decodes hex data with or without 0x prefix
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	return hex.DecodeString(s)
+}
+
+// DecodeInput decodes the function call made by a transaction, matching the
+// 4-byte selector of its data against the functions of the ABI. Integer
+// arguments are decoded as *big.Int, addresses as lowercase hex strings,
+// bytes as []byte, and arrays and tuples as []interface{}
+func DecodeInput(abi *ABI, tx *Transaction) (*DecodedInput, error) {
+	if abi == nil || tx == nil {
+		return nil, errors.New("ABI and transaction are required")
+	}
+	data, err := decodeHex(tx.Data)
+	if err != nil {
+		return nil, errors.New("Could not decode transaction data: " + err.Error())
+	}
+	if len(data) < 4 {
+		return nil, errors.New("Transaction data has no function selector")
+	}
+
+	selector := "0x" + hex.EncodeToString(data[:4])
+	method := abi.FunctionBySelector(selector)
+	if method == nil {
+		return nil, fmt.Errorf("No function in ABI with selector %s", selector)
+	}
+
+	values, err := decodeABIArguments(method.Inputs, data[4:])
+	if err != nil {
+		return nil, err
+	}
+	decoded := &DecodedInput{
+		Method: method,
+		Args:   make([]DecodedArgument, len(values)),
+	}
+	for i, v := range values {
+		decoded.Args[i] = DecodedArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].CanonicalType(),
+			Value: v,
+		}
+	}
+	return decoded, nil
+}
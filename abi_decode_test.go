@@ -0,0 +1,134 @@
+package etherscan
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Joins hex encoded words into transaction data
+func abiTestData(selector string, words ...string) string {
+	return selector + strings.Join(words, "")
+}
+
+func TestDecodeInput(t *testing.T) {
+	assert := assert.New(t)
+	abi := loadTestABI(t)
+
+	tx := &Transaction{
+		Data: abiTestData("0xa9059cbb",
+			"000000000000000000000000ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a",
+			"00000000000000000000000000000000000000000000021e19e0c9bab2400000",
+		),
+	}
+	decoded, err := DecodeInput(abi, tx)
+	assert.NoError(err)
+	assert.Equal("transfer", decoded.Method.Name)
+	assert.Len(decoded.Args, 2)
+	assert.Equal("0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", decoded.Args[0].Value)
+	assert.Equal("address", decoded.Args[0].Type)
+
+	amount, ok := decoded.Arg("_value")
+	assert.True(ok)
+	val := &big.Int{}
+	val.SetString("10000000000000000000000", 10)
+	assert.EqualValues(val, amount)
+
+	_, err = DecodeInput(abi, &Transaction{Data: "0x"})
+	assert.Error(err)
+	_, err = DecodeInput(abi, &Transaction{Data: "0xdeadbeef"})
+	assert.Error(err)
+	_, err = DecodeInput(abi, &Transaction{Data: "0xa9059cbb0000"})
+	assert.Error(err)
+}
+
+func TestDecodeInputDynamic(t *testing.T) {
+	assert := assert.New(t)
+	abi, err := ParseABI([]byte(`[
+		{"type":"function","name":"f","inputs":[
+			{"name":"a","type":"uint256"},
+			{"name":"b","type":"uint32[]"},
+			{"name":"c","type":"bytes10"},
+			{"name":"d","type":"bytes"}
+		]},
+		{"type":"function","name":"g","inputs":[
+			{"name":"a","type":"uint256[][]"},
+			{"name":"b","type":"string[]"}
+		]},
+		{"type":"function","name":"h","inputs":[
+			{"name":"t","type":"tuple","components":[
+				{"name":"n","type":"uint256"},
+				{"name":"s","type":"string"}
+			]},
+			{"name":"i","type":"int8"},
+			{"name":"ok","type":"bool"}
+		]}
+	]`))
+	assert.NoError(err)
+
+	// Examples from the Solidity ABI specification
+	decoded, err := DecodeInput(abi, &Transaction{Data: abiTestData("0x8be65246",
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	)})
+	assert.NoError(err)
+	assert.Equal("f", decoded.Method.Name)
+	assert.EqualValues(big.NewInt(0x123), decoded.Args[0].Value)
+	assert.EqualValues([]interface{}{big.NewInt(0x456), big.NewInt(0x789)}, decoded.Args[1].Value)
+	assert.Equal([]byte("1234567890"), decoded.Args[2].Value)
+	assert.Equal([]byte("Hello, world!"), decoded.Args[3].Value)
+
+	decoded, err = DecodeInput(abi, &Transaction{Data: abiTestData("0x2289b18c",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000140",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"6f6e650000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"74776f0000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"7468726565000000000000000000000000000000000000000000000000000000",
+	)})
+	assert.NoError(err)
+	assert.Equal("g", decoded.Method.Name)
+	assert.EqualValues([]interface{}{
+		[]interface{}{big.NewInt(1), big.NewInt(2)},
+		[]interface{}{big.NewInt(3)},
+	}, decoded.Args[0].Value)
+	assert.Equal([]interface{}{"one", "two", "three"}, decoded.Args[1].Value)
+
+	decoded, err = DecodeInput(abi, &Transaction{Data: abiTestData(abi.Function("h").Selector(),
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"6869000000000000000000000000000000000000000000000000000000000000",
+	)})
+	assert.NoError(err)
+	assert.Equal("(uint256,string)", decoded.Args[0].Type)
+	assert.EqualValues([]interface{}{big.NewInt(5), "hi"}, decoded.Args[0].Value)
+	assert.EqualValues(big.NewInt(-1), decoded.Args[1].Value)
+	assert.Equal(true, decoded.Args[2].Value)
+}
@@ -0,0 +1,243 @@
+package etherscan
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Converts a Go integer to *big.Int
+func abiBigInt(v interface{}) (*big.Int, bool) {
+	switch n := v.(type) {
+	case *big.Int:
+		if n == nil {
+			return nil, false
+		}
+		return n, true
+	case big.Int:
+		return &n, true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+// Left pads b to a full word
+func abiPadLeft(b []byte) []byte {
+	word := make([]byte, abiWordSize)
+	copy(word[abiWordSize-len(b):], b)
+	return word
+}
+
+// Right pads b to a multiple of the word size
+func abiPadRight(b []byte) []byte {
+	size := (len(b) + abiWordSize - 1) / abiWordSize * abiWordSize
+	padded := make([]byte, size)
+	copy(padded, b)
+	return padded
+}
+
+// Returns the elements of a slice or array value
+func abiElements(v interface{}) ([]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+	return elems, true
+}
+
+// Encodes a value of type t. Static values are encoded in place, dynamic
+// values return the content their offset points to.
+// Integers may be any Go integer or *big.Int, addresses hex strings, bytes
+// []byte, and arrays and tuples any slice
+func encodeABIValue(t *abiType, v interface{}) ([]byte, error) {
+	switch t.kind {
+	case "uint", "int":
+		n, ok := abiBigInt(v)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode %T as %s%d", v, t.kind, t.size)
+		}
+		if t.kind == "uint" {
+			if n.Sign() < 0 || n.BitLen() > t.size {
+				return nil, fmt.Errorf("Value %s out of range for uint%d", n, t.size)
+			}
+			return abiPadLeft(n.Bytes()), nil
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("Value %s out of range for int%d", n, t.size)
+		}
+		if n.Sign() < 0 {
+			// Two's complement
+			n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return abiPadLeft(n.Bytes()), nil
+	case "address":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode %T as address", v)
+		}
+		b, err := decodeHex(s)
+		if err != nil || !strings.HasPrefix(s, "0x") || len(b) != 20 {
+			return nil, fmt.Errorf("Invalid address: %s", s)
+		}
+		return abiPadLeft(b), nil
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode %T as bool", v)
+		}
+		if b {
+			return abiPadLeft([]byte{1}), nil
+		}
+		return abiPadLeft(nil), nil
+	case "fixedbytes":
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode %T as bytes%d", v, t.size)
+		}
+		if len(b) != t.size {
+			return nil, fmt.Errorf("Value of %d bytes given for bytes%d", len(b), t.size)
+		}
+		return abiPadRight(b), nil
+	case "bytes", "string":
+		var b []byte
+		switch s := v.(type) {
+		case []byte:
+			if t.kind != "bytes" {
+				return nil, fmt.Errorf("Cannot encode %T as %s", v, t.kind)
+			}
+			b = s
+		case string:
+			if t.kind != "string" {
+				return nil, fmt.Errorf("Cannot encode %T as %s", v, t.kind)
+			}
+			b = []byte(s)
+		default:
+			return nil, fmt.Errorf("Cannot encode %T as %s", v, t.kind)
+		}
+		length := abiPadLeft(big.NewInt(int64(len(b))).Bytes())
+		return append(length, abiPadRight(b)...), nil
+	case "slice", "array", "tuple":
+		elems, ok := abiElements(v)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode %T as %s", v, t.kind)
+		}
+		types := t.fields
+		if t.kind != "tuple" {
+			types = make([]*abiType, len(elems))
+			for i := range types {
+				types[i] = t.elem
+			}
+		}
+		if t.kind == "array" && len(elems) != t.length {
+			return nil, fmt.Errorf("Array of %d values given for length %d", len(elems), t.length)
+		}
+		if len(elems) != len(types) {
+			return nil, fmt.Errorf("Tuple of %d values given for %d fields", len(elems), len(types))
+		}
+		encoded, err := encodeABISequence(types, elems)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == "slice" {
+			length := abiPadLeft(big.NewInt(int64(len(elems))).Bytes())
+			return append(length, encoded...), nil
+		}
+		return encoded, nil
+	}
+	return nil, fmt.Errorf("Unsupported ABI type: %s", t.kind)
+}
+
+// Encodes consecutive values, placing dynamic values after the heads of all
+// values
+func encodeABISequence(types []*abiType, values []interface{}) ([]byte, error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		encoded, err := encodeABIValue(t, values[i])
+		if err != nil {
+			return nil, err
+		}
+		if t.static() {
+			head = append(head, encoded...)
+			continue
+		}
+		offset := big.NewInt(int64(headSize + len(tail)))
+		head = append(head, abiPadLeft(offset.Bytes())...)
+		tail = append(tail, encoded...)
+	}
+	return append(head, tail...), nil
+}
+
+// Encodes Go values as ABI arguments
+func encodeABIArguments(args []ABIArgument, values []interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("%d arguments given, %d expected", len(values), len(args))
+	}
+	types := make([]*abiType, len(args))
+	for i := range args {
+		t, err := parseABIType(&args[i])
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return encodeABISequence(types, values)
+}
+
+// Finds a function by name, or by signature if method has an argument list
+func lookupFunction(abi *ABI, method string) (*ABIMethod, error) {
+	if abi == nil {
+		return nil, errors.New("ABI is required")
+	}
+	var m *ABIMethod
+	if strings.Contains(method, "(") {
+		m = abi.FunctionBySignature(method)
+	} else {
+		m = abi.Function(method)
+	}
+	if m == nil {
+		return nil, fmt.Errorf("No function in ABI matching %s", method)
+	}
+	return m, nil
+}
+
+// Encodes a call to the function, prefixed with its selector
+func encodeCall(m *ABIMethod, args []interface{}) ([]byte, error) {
+	encoded, err := encodeABIArguments(m.Inputs, args)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode arguments of %s: %s", m.Signature(), err)
+	}
+	selector, _ := hex.DecodeString(strings.TrimPrefix(m.Selector(), "0x"))
+	return append(selector, encoded...), nil
+}
+
+// EncodeInput encodes a call to a function of the ABI, prefixed with its
+// selector. The function is given by name, or by signature such as
+// "safeTransferFrom(address,address,uint256)" to pick an overload. Integer
+// arguments may be any Go integer or *big.Int, addresses hex strings, bytes
+// []byte, and arrays and tuples any slice
+func EncodeInput(abi *ABI, method string, args ...interface{}) ([]byte, error) {
+	m, err := lookupFunction(abi, method)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCall(m, args)
+}
@@ -0,0 +1,113 @@
+package etherscan
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeInput(t *testing.T) {
+	assert := assert.New(t)
+	abi := loadTestABI(t)
+
+	value := &big.Int{}
+	value.SetString("10000000000000000000000", 10)
+	data, err := EncodeInput(abi, "transfer", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", value)
+	assert.NoError(err)
+	assert.Equal(abiTestData("a9059cbb",
+		"000000000000000000000000ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a",
+		"00000000000000000000000000000000000000000000021e19e0c9bab2400000",
+	), hex.EncodeToString(data))
+
+	_, err = EncodeInput(abi, "transfer", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a")
+	assert.Error(err)
+	_, err = EncodeInput(abi, "transfer", "ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", 1)
+	assert.Error(err)
+	_, err = EncodeInput(abi, "transfer", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", -1)
+	assert.Error(err)
+	_, err = EncodeInput(abi, "noSuchMethod")
+	assert.Error(err)
+}
+
+func TestEncodeInputDynamic(t *testing.T) {
+	assert := assert.New(t)
+	abi, err := ParseABI([]byte(`[
+		{"type":"function","name":"f","inputs":[
+			{"name":"a","type":"uint256"},
+			{"name":"b","type":"uint32[]"},
+			{"name":"c","type":"bytes10"},
+			{"name":"d","type":"bytes"}
+		]},
+		{"type":"function","name":"g","inputs":[
+			{"name":"a","type":"uint256[][]"},
+			{"name":"b","type":"string[]"}
+		]},
+		{"type":"function","name":"h","inputs":[
+			{"name":"t","type":"tuple","components":[
+				{"name":"n","type":"uint256"},
+				{"name":"s","type":"string"}
+			]},
+			{"name":"i","type":"int8"},
+			{"name":"ok","type":"bool"}
+		]}
+	]`))
+	assert.NoError(err)
+
+	// Examples from the Solidity ABI specification
+	data, err := EncodeInput(abi, "f", 0x123, []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!"))
+	assert.NoError(err)
+	assert.Equal(abiTestData("8be65246",
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	), hex.EncodeToString(data))
+
+	data, err = EncodeInput(abi, "g", [][]int{{1, 2}, {3}}, []string{"one", "two", "three"})
+	assert.NoError(err)
+	assert.Equal(abiTestData("2289b18c",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000140",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"6f6e650000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"74776f0000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"7468726565000000000000000000000000000000000000000000000000000000",
+	), hex.EncodeToString(data))
+
+	// Decoding the encoded call gives back the arguments
+	data, err = EncodeInput(abi, "h", []interface{}{big.NewInt(5), "hi"}, -1, true)
+	assert.NoError(err)
+	decoded, err := DecodeInput(abi, &Transaction{Data: "0x" + hex.EncodeToString(data)})
+	assert.NoError(err)
+	assert.EqualValues([]interface{}{big.NewInt(5), "hi"}, decoded.Args[0].Value)
+	assert.EqualValues(big.NewInt(-1), decoded.Args[1].Value)
+	assert.Equal(true, decoded.Args[2].Value)
+
+	_, err = EncodeInput(abi, "h", []interface{}{5}, 0, true)
+	assert.Error(err)
+	_, err = EncodeInput(abi, "h", []interface{}{5, "hi"}, 128, true)
+	assert.Error(err)
+	_, err = EncodeInput(abi, "f", 1, []int{1}, []byte("short"), []byte{})
+	assert.Error(err)
+}
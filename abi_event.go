@@ -0,0 +1,295 @@
+package etherscan
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DecodedEvent is an event log decoded against its ABI definition
+type DecodedEvent struct {
+	Event *ABIEvent
+	Name  string
+	Args  []DecodedArgument
+}
+
+// Arg returns the value of the argument with the given name
+func (d *DecodedEvent) Arg(name string) (interface{}, bool) {
+	for _, a := range d.Args {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return nil, false
+}
+
+// TransferEvent is an ERC20 or ERC721 Transfer event
+type TransferEvent struct {
+	// Address of the token contract
+	Token string
+	From  string
+	To    string
+	// Amount transferred, for ERC20 tokens
+	Value *big.Int
+	// ID of the transferred token, for ERC721 tokens
+	TokenID *big.Int
+}
+
+// ApprovalEvent is an ERC20 or ERC721 Approval event
+type ApprovalEvent struct {
+	// Address of the token contract
+	Token   string
+	Owner   string
+	Spender string
+	// Amount approved, for ERC20 tokens
+	Value *big.Int
+	// ID of the approved token, for ERC721 tokens
+	TokenID *big.Int
+}
+
+// ParseEventSignature parses an event signature such as
+// "Transfer(address indexed from,address indexed to,uint256 value)".
+// Argument names are optional
+func ParseEventSignature(sig string) (*ABIEvent, error) {
+	name, args, err := parseSignature(strings.TrimPrefix(strings.TrimSpace(sig), "event "))
+	if err != nil {
+		return nil, err
+	}
+	return &ABIEvent{Name: name, Inputs: args}, nil
+}
+
+// Parses a signature into its name and arguments
+func parseSignature(sig string) (string, []ABIArgument, error) {
+	open := strings.Index(sig, "(")
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("Invalid signature: %s", sig)
+	}
+	args, err := parseSignatureArguments(sig[open+1 : len(sig)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("Invalid signature: %s", sig)
+	}
+	return strings.TrimSpace(sig[:open]), args, nil
+}
+
+// Parses a comma separated argument list, with tuples written as
+// parenthesized type lists
+func parseSignatureArguments(list string) ([]ABIArgument, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	// Split on commas outside of tuples
+	var parts []string
+	depth, start := 0, 0
+	for i, ch := range list {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("Unbalanced parentheses")
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("Unbalanced parentheses")
+	}
+	parts = append(parts, list[start:])
+
+	args := make([]ABIArgument, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		arg := ABIArgument{}
+		if strings.HasPrefix(part, "(") {
+			end := strings.LastIndex(part, ")")
+			components, err := parseSignatureArguments(part[1:end])
+			if err != nil {
+				return nil, err
+			}
+			arg.Components = components
+			// Keep array suffixes such as "[]" after the tuple
+			rest := part[end+1:]
+			suffix := rest
+			if j := strings.IndexAny(rest, " \t"); j >= 0 {
+				suffix = rest[:j]
+			}
+			arg.Type = "tuple" + suffix
+			part = arg.Type + rest[len(suffix):]
+		}
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return nil, errors.New("Empty argument")
+		}
+		if arg.Type == "" {
+			arg.Type = fields[0]
+		}
+		for _, f := range fields[1:] {
+			if f == "indexed" {
+				arg.Indexed = true
+			} else {
+				arg.Name = f
+			}
+		}
+		if _, err := parseABIType(&arg); err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// Decode decodes a log of this event. Indexed arguments are read from the
+// topics and the others from the data. Indexed arguments of dynamic types,
+// such as strings, are stored as their Keccak-256 hash and decoded as the
+// 32-byte hash
+func (e *ABIEvent) Decode(log EventLog) (*DecodedEvent, error) {
+	topics := log.Topics
+	if !e.Anonymous {
+		if len(topics) == 0 || !strings.EqualFold(topics[0], e.Topic()) {
+			return nil, fmt.Errorf("Log is not a %s event", e.Name)
+		}
+		topics = topics[1:]
+	}
+
+	var indexed, unindexed []ABIArgument
+	for _, arg := range e.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		} else {
+			unindexed = append(unindexed, arg)
+		}
+	}
+	if len(topics) != len(indexed) {
+		return nil, fmt.Errorf("Log has %d indexed arguments, %s expects %d", len(topics), e.Name, len(indexed))
+	}
+
+	data, err := decodeHex(log.Data)
+	if err != nil {
+		return nil, errors.New("Could not decode log data: " + err.Error())
+	}
+	values, err := decodeABIArguments(unindexed, data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedEvent{
+		Event: e,
+		Name:  e.Name,
+		Args:  make([]DecodedArgument, len(e.Inputs)),
+	}
+	ti, di := 0, 0
+	for i, arg := range e.Inputs {
+		var value interface{}
+		if arg.Indexed {
+			value, err = decodeTopic(&arg, topics[ti])
+			if err != nil {
+				return nil, err
+			}
+			ti++
+		} else {
+			value = values[di]
+			di++
+		}
+		decoded.Args[i] = DecodedArgument{
+			Name:  arg.Name,
+			Type:  arg.CanonicalType(),
+			Value: value,
+		}
+	}
+	return decoded, nil
+}
+
+// Decodes an indexed argument from its topic
+func decodeTopic(arg *ABIArgument, topic string) (interface{}, error) {
+	word, err := decodeHex(topic)
+	if err != nil || len(word) != abiWordSize {
+		return nil, fmt.Errorf("Invalid topic: %s", topic)
+	}
+	t, err := parseABIType(arg)
+	if err != nil {
+		return nil, err
+	}
+	if !t.static() || t.kind == "array" || t.kind == "tuple" {
+		return word, nil
+	}
+	return decodeABIValue(t, word, 0)
+}
+
+// DecodeEventLog decodes a log against the event of the ABI matching its
+// topic0 hash
+func DecodeEventLog(abi *ABI, log EventLog) (*DecodedEvent, error) {
+	if abi == nil {
+		return nil, errors.New("ABI is required")
+	}
+	if len(log.Topics) == 0 {
+		return nil, errors.New("Log has no topics")
+	}
+	event := abi.EventByTopic(log.Topics[0])
+	if event == nil {
+		return nil, fmt.Errorf("No event in ABI with topic %s", log.Topics[0])
+	}
+	return event.Decode(log)
+}
+
+// Decodes the three arguments shared by Transfer and Approval events. ERC20
+// events index two addresses and carry the amount in the data, while ERC721
+// events also index the token ID
+func decodeTokenEvent(log EventLog, name string) (string, string, *big.Int, *big.Int, error) {
+	nft := len(log.Topics) == 4
+	event := &ABIEvent{
+		Name: name,
+		Inputs: []ABIArgument{
+			{Type: "address", Indexed: true},
+			{Type: "address", Indexed: true},
+			{Type: "uint256", Indexed: nft},
+		},
+	}
+	decoded, err := event.Decode(log)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	var value, tokenID *big.Int
+	if nft {
+		tokenID = decoded.Args[2].Value.(*big.Int)
+	} else {
+		value = decoded.Args[2].Value.(*big.Int)
+	}
+	return decoded.Args[0].Value.(string), decoded.Args[1].Value.(string), value, tokenID, nil
+}
+
+// DecodeTransfer decodes an ERC20 or ERC721 Transfer event
+func DecodeTransfer(log EventLog) (*TransferEvent, error) {
+	from, to, value, tokenID, err := decodeTokenEvent(log, "Transfer")
+	if err != nil {
+		return nil, err
+	}
+	return &TransferEvent{
+		Token:   log.Address,
+		From:    from,
+		To:      to,
+		Value:   value,
+		TokenID: tokenID,
+	}, nil
+}
+
+// DecodeApproval decodes an ERC20 or ERC721 Approval event
+func DecodeApproval(log EventLog) (*ApprovalEvent, error) {
+	owner, spender, value, tokenID, err := decodeTokenEvent(log, "Approval")
+	if err != nil {
+		return nil, err
+	}
+	return &ApprovalEvent{
+		Token:   log.Address,
+		Owner:   owner,
+		Spender: spender,
+		Value:   value,
+		TokenID: tokenID,
+	}, nil
+}
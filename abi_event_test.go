@@ -0,0 +1,98 @@
+package etherscan
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTransferLog = EventLog{
+	Address: "0xdac17f958d2ee523a2206206994597c13d831ec7",
+	Topics: []string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x000000000000000000000000ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a",
+		"0x0000000000000000000000001bb0ac60363e320bc45fdb15aed226fb59c88e44",
+	},
+	Data: "0x00000000000000000000000000000000000000000000000000000000000f4240",
+}
+
+func TestParseEventSignature(t *testing.T) {
+	assert := assert.New(t)
+
+	event, err := ParseEventSignature("Transfer(address indexed from, address indexed to, uint256 value)")
+	assert.NoError(err)
+	assert.Equal("Transfer", event.Name)
+	assert.Equal("Transfer(address,address,uint256)", event.Signature())
+	assert.True(event.Inputs[0].Indexed)
+	assert.Equal("from", event.Inputs[0].Name)
+	assert.False(event.Inputs[2].Indexed)
+
+	event, err = ParseEventSignature("event Settled((address,uint256[2])[] orders,bytes32 indexed id)")
+	assert.NoError(err)
+	assert.Equal("Settled((address,uint256[2])[],bytes32)", event.Signature())
+	assert.Equal("orders", event.Inputs[0].Name)
+	assert.Len(event.Inputs[0].Components, 2)
+	assert.True(event.Inputs[1].Indexed)
+
+	_, err = ParseEventSignature("Transfer(address,address")
+	assert.Error(err)
+	_, err = ParseEventSignature("Transfer(adress,address,uint256)")
+	assert.Error(err)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	event, err := ParseEventSignature("Transfer(address indexed from,address indexed to,uint256 value)")
+	assert.NoError(err)
+	decoded, err := event.Decode(testTransferLog)
+	assert.NoError(err)
+	assert.Equal("Transfer", decoded.Name)
+	from, _ := decoded.Arg("from")
+	assert.Equal("0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", from)
+	value, _ := decoded.Arg("value")
+	assert.EqualValues(big.NewInt(1000000), value)
+
+	decoded, err = DecodeEventLog(loadTestABI(t), testTransferLog)
+	assert.NoError(err)
+	to, ok := decoded.Arg("_to")
+	assert.True(ok)
+	assert.Equal("0x1bb0ac60363e320bc45fdb15aed226fb59c88e44", to)
+
+	event, err = ParseEventSignature("Transfer(address,address,uint256)")
+	assert.NoError(err)
+	_, err = event.Decode(testTransferLog)
+	assert.Error(err)
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	transfer, err := DecodeTransfer(testTransferLog)
+	assert.NoError(err)
+	assert.Equal("0xdac17f958d2ee523a2206206994597c13d831ec7", transfer.Token)
+	assert.Equal("0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", transfer.From)
+	assert.EqualValues(big.NewInt(1000000), transfer.Value)
+	assert.Nil(transfer.TokenID)
+
+	nftLog := EventLog{
+		Address: "0x06012c8cf97bead5deae237070f9587f8e7a266d",
+		Topics:  append(append([]string{}, testTransferLog.Topics...), "0x000000000000000000000000000000000000000000000000000000000003157a"),
+		Data:    "0x",
+	}
+	transfer, err = DecodeTransfer(nftLog)
+	assert.NoError(err)
+	assert.EqualValues(big.NewInt(202106), transfer.TokenID)
+	assert.Nil(transfer.Value)
+
+	_, err = DecodeApproval(testTransferLog)
+	assert.Error(err)
+
+	approvalLog := testTransferLog
+	approvalLog.Topics = append([]string{"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"}, testTransferLog.Topics[1:]...)
+	approval, err := DecodeApproval(approvalLog)
+	assert.NoError(err)
+	assert.Equal("0x1bb0ac60363e320bc45fdb15aed226fb59c88e44", approval.Spender)
+	assert.EqualValues(big.NewInt(1000000), approval.Value)
+}
@@ -0,0 +1,78 @@
+package etherscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseABI(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "abi.json")
+	rawABI, err := parseABIResponse(r)
+	assert.NoError(err)
+
+	abi, err := ParseABI(rawABI)
+	assert.NoError(err)
+	assert.NotNil(abi.Constructor)
+	assert.Len(abi.Functions, 46)
+
+	transfer := abi.Function("transfer")
+	assert.Equal("transfer(address,uint256)", transfer.Signature())
+	assert.Equal("0xa9059cbb", transfer.Selector())
+	assert.Equal(StateNonPayable, transfer.StateMutability)
+	assert.Equal("bool", transfer.Outputs[0].Type)
+	assert.Equal(transfer, abi.FunctionBySelector("0xA9059CBB"))
+
+	assert.Equal(StateView, abi.Function("balanceOf").StateMutability)
+
+	event := abi.Event("Transfer")
+	assert.Equal("Transfer(address,address,uint256)", event.Signature())
+	assert.Equal("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", event.Topic())
+	assert.True(event.Inputs[0].Indexed)
+	assert.False(event.Inputs[2].Indexed)
+	assert.Equal(event, abi.EventByTopic(event.Topic()))
+}
+
+func TestParseABITuples(t *testing.T) {
+	assert := assert.New(t)
+	abi, err := ParseABI([]byte(`[
+		{"type":"function","name":"submit","stateMutability":"payable","inputs":[
+			{"name":"orders","type":"tuple[]","components":[
+				{"name":"maker","type":"address"},
+				{"name":"amounts","type":"uint256[2]"}
+			]},
+			{"name":"data","type":"bytes"}
+		],"outputs":[]},
+		{"type":"error","name":"InsufficientBalance","inputs":[
+			{"name":"available","type":"uint256"},
+			{"name":"required","type":"uint256"}
+		]},
+		{"type":"receive","stateMutability":"payable"}
+	]`))
+	assert.NoError(err)
+
+	submit := abi.Function("submit")
+	assert.Equal("submit((address,uint256[2])[],bytes)", submit.Signature())
+	assert.Equal(StatePayable, submit.StateMutability)
+	assert.NotNil(abi.Receive)
+
+	insufficient := abi.Error("InsufficientBalance")
+	assert.Equal("0xcf479181", insufficient.Selector())
+
+	_, err = ParseABI([]byte(`[{"type":"unknown"}]`))
+	assert.Error(err)
+}
+
+// Loads the parsed ABI of the test fixture
+func loadTestABI(t *testing.T) *ABI {
+	rawABI, err := parseABIResponse(loadTestData(t, "abi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := ParseABI(rawABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return abi
+}
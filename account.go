@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
+// Maximum number of addresses accepted by a single balancemulti request
+const balanceMultiLimit = 20
+
 // Response with balance of a single address
 type balanceResponse struct {
 	*baseResponse
@@ -36,21 +41,116 @@ func parseBalanceResponse(r io.Reader) (*big.Int, error) {
 	return bal, nil
 }
 
-func (c *Client) buildBalanceRequest(addr string) (*http.Request, error) {
+// Response with balances of several addresses
+type balanceMultiResponse struct {
+	*baseResponse
+	Result json.RawMessage `json:"result"`
+}
+
+// An unparsed balance of a single address in a balancemulti response
+type accountBalanceResponse struct {
+	Account string `json:"account"`
+	Balance string `json:"balance"`
+}
+
+// Parses a balancemulti response into a map of address to balance
+func parseBalanceMultiResponse(r io.Reader) (map[string]*big.Int, error) {
+	res := &balanceMultiResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+	if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+		return nil, err
+	}
+	var results []*accountBalanceResponse
+	if err := json.Unmarshal(res.Result, &results); err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*big.Int, len(results))
+	for _, b := range results {
+		bal, ok := (&big.Int{}).SetString(b.Balance, 10)
+		if !ok {
+			return nil, fmt.Errorf("Could not parse balance of %s: %s", b.Account, b.Balance)
+		}
+		balances[b.Account] = bal
+	}
+	return balances, nil
+}
+
+func (c *Client) buildBalanceRequest(addr string, tag BlockTag) (*http.Request, error) {
 	if !strings.HasPrefix(addr, "0x") {
 		return nil, errors.New("Address must begin with 0x")
 	}
+	tagParam, err := tag.param()
+	if err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("module", "account")
 	params.Set("action", "balance")
-	params.Set("tag", "latest")
+	params.Set("tag", tagParam)
+	params.Set("address", addr)
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildBalanceHistoryRequest(addr string, blockNumber int) (*http.Request, error) {
+	if !strings.HasPrefix(addr, "0x") {
+		return nil, errors.New("Address must begin with 0x")
+	}
+	if blockNumber < 0 {
+		return nil, errors.New("Block number must be >= 0")
+	}
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "balancehistory")
 	params.Set("address", addr)
+	params.Set("blockno", strconv.Itoa(blockNumber))
 
 	return c.buildRequest(params)
 }
 
-func (c *Client) balance(ctx context.Context, addr string) (*big.Int, error) {
-	req, err := c.buildBalanceRequest(addr)
+func (c *Client) buildBalanceMultiRequest(addrs []string, tag BlockTag) (*http.Request, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("At least one address is required")
+	}
+	if len(addrs) > balanceMultiLimit {
+		return nil, fmt.Errorf("At most %d addresses are allowed per request", balanceMultiLimit)
+	}
+	for _, addr := range addrs {
+		if !strings.HasPrefix(addr, "0x") {
+			return nil, errors.New("Address must begin with 0x")
+		}
+	}
+	tagParam, err := tag.param()
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "balancemulti")
+	params.Set("tag", tagParam)
+	params.Set("address", strings.Join(addrs, ","))
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) balance(ctx context.Context, addr string, tag BlockTag) (*big.Int, error) {
+	req, err := c.buildBalanceRequest(addr, tag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseBalanceResponse(resp.Body)
+}
+
+func (c *Client) balanceHistory(ctx context.Context, addr string, blockNumber int) (*big.Int, error) {
+	req, err := c.buildBalanceHistoryRequest(addr, blockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -64,11 +164,81 @@ func (c *Client) balance(ctx context.Context, addr string) (*big.Int, error) {
 
 // Balance returns the balance of a single address
 func (c *Client) Balance(addr string) (*big.Int, error) {
-	return c.balance(context.Background(), addr)
+	return c.balance(context.Background(), addr, BlockTagLatest)
 }
 
 // BalanceContext returns the balance of a single address with a custom
 // context
 func (c *Client) BalanceContext(ctx context.Context, addr string) (*big.Int, error) {
-	return c.balance(ctx, addr)
+	return c.balance(ctx, addr, BlockTagLatest)
+}
+
+// BalanceAt returns the balance of a single address at the given block tag
+func (c *Client) BalanceAt(addr string, tag BlockTag) (*big.Int, error) {
+	return c.balance(context.Background(), addr, tag)
+}
+
+// BalanceAtContext returns the balance of a single address at the given
+// block tag with a custom context
+func (c *Client) BalanceAtContext(ctx context.Context, addr string, tag BlockTag) (*big.Int, error) {
+	return c.balance(ctx, addr, tag)
+}
+
+// BalanceHistory returns the historical balance of a single address at the
+// given block number
+func (c *Client) BalanceHistory(addr string, blockNumber int) (*big.Int, error) {
+	return c.balanceHistory(context.Background(), addr, blockNumber)
+}
+
+// BalanceHistoryContext returns the historical balance of a single address
+// at the given block number with a custom context
+func (c *Client) BalanceHistoryContext(ctx context.Context, addr string, blockNumber int) (*big.Int, error) {
+	return c.balanceHistory(ctx, addr, blockNumber)
+}
+
+func (c *Client) balanceMulti(ctx context.Context, addrs []string, tag BlockTag) (map[string]*big.Int, error) {
+	balances := make(map[string]*big.Int, len(addrs))
+	for _, chunk := range chunkAddresses(addrs, balanceMultiLimit) {
+		req, err := c.buildBalanceMultiRequest(chunk, tag)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.sendRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		chunkBalances, err := parseBalanceMultiResponse(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for addr, bal := range chunkBalances {
+			balances[addr] = bal
+		}
+	}
+	return balances, nil
+}
+
+// BalanceMulti returns the balances of several addresses, keyed by address.
+// Addresses are sent in batches of 20, the maximum allowed by the API
+func (c *Client) BalanceMulti(addrs []string) (map[string]*big.Int, error) {
+	return c.balanceMulti(context.Background(), addrs, BlockTagLatest)
+}
+
+// BalanceMultiContext returns the balances of several addresses, keyed by
+// address, with a custom context
+func (c *Client) BalanceMultiContext(ctx context.Context, addrs []string) (map[string]*big.Int, error) {
+	return c.balanceMulti(ctx, addrs, BlockTagLatest)
+}
+
+// BalanceMultiAt returns the balances of several addresses at the given
+// block tag, keyed by address
+func (c *Client) BalanceMultiAt(addrs []string, tag BlockTag) (map[string]*big.Int, error) {
+	return c.balanceMulti(context.Background(), addrs, tag)
+}
+
+// BalanceMultiAtContext returns the balances of several addresses at the
+// given block tag, keyed by address, with a custom context
+func (c *Client) BalanceMultiAtContext(ctx context.Context, addrs []string, tag BlockTag) (map[string]*big.Int, error) {
+	return c.balanceMulti(ctx, addrs, tag)
 }
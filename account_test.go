@@ -2,6 +2,7 @@ package etherscan
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,3 +17,58 @@ func TestBalance(t *testing.T) {
 	assert.NoError(err)
 	assert.EqualValues(expected, bal)
 }
+
+func TestBalanceMulti(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "balance_multi.json")
+	balances, err := parseBalanceMultiResponse(r)
+	assert.NoError(err)
+	assert.Len(balances, 3)
+
+	expected := &big.Int{}
+	expected.SetString("40891626854930000000999", 10)
+	assert.EqualValues(expected, balances["0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a"])
+	assert.EqualValues(big.NewInt(0), balances["0x198ef1ec325a96cc354c7266a038be8b5c558f67"])
+
+	_, err = parseBalanceMultiResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+	assert.EqualError(err, "API Error: Invalid API Key")
+}
+
+func TestBuildBalanceMultiRequest(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{}
+
+	addrs := make([]string, balanceMultiLimit+1)
+	for i := range addrs {
+		addrs[i] = "0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c"
+	}
+	_, err := c.buildBalanceMultiRequest(addrs, BlockTagLatest)
+	assert.Error(err)
+
+	req, err := c.buildBalanceMultiRequest(addrs[:2], "")
+	assert.NoError(err)
+	assert.Equal("balancemulti", req.URL.Query().Get("action"))
+	assert.Equal(addrs[0]+","+addrs[1], req.URL.Query().Get("address"))
+	assert.Equal("latest", req.URL.Query().Get("tag"))
+
+	req, err = c.buildBalanceMultiRequest(addrs[:2], BlockTagPending)
+	assert.NoError(err)
+	assert.Equal("pending", req.URL.Query().Get("tag"))
+
+	_, err = c.buildBalanceMultiRequest(addrs[:2], BlockTag("1000"))
+	assert.Error(err)
+}
+
+func TestBuildBalanceHistoryRequest(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{}
+
+	req, err := c.buildBalanceHistoryRequest("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", 8000000)
+	assert.NoError(err)
+	assert.Equal("balancehistory", req.URL.Query().Get("action"))
+	assert.Equal("8000000", req.URL.Query().Get("blockno"))
+
+	req, err = c.buildBalanceRequest("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", BlockTagPending)
+	assert.NoError(err)
+	assert.Equal("pending", req.URL.Query().Get("tag"))
+}
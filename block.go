@@ -4,18 +4,70 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
-// Block is a single block in the chain
+// BlockTag selects the state of the chain a query is run against. It is
+// either one of the named tags below or a block number from BlockNumberTag
+type BlockTag string
+
+const (
+	BlockTagLatest   BlockTag = "latest"
+	BlockTagPending  BlockTag = "pending"
+	BlockTagEarliest BlockTag = "earliest"
+)
+
+// BlockNumberTag returns the tag of a specific block number
+func BlockNumberTag(blockNumber int) BlockTag {
+	return BlockTag(fmt.Sprintf("0x%x", blockNumber))
+}
+
+// Returns the tag as a request parameter, defaulting to latest
+func (t BlockTag) param() (string, error) {
+	switch t {
+	case "":
+		return string(BlockTagLatest), nil
+	case BlockTagLatest, BlockTagPending, BlockTagEarliest:
+		return string(t), nil
+	}
+	if !strings.HasPrefix(string(t), "0x") {
+		return "", fmt.Errorf("Invalid block tag: %s", t)
+	}
+	if _, err := strconv.ParseUint(string(t)[2:], 16, 64); err != nil {
+		return "", fmt.Errorf("Invalid block tag: %s", t)
+	}
+	return string(t), nil
+}
+
+// Block is a single block in the chain. Transaction lists only set Number
+// and Hash, the other fields are set by BlockByNumber
 type Block struct {
-	Number int
-	Hash   string
-	// All transactions mined in this block
+	Number     int
+	Hash       string
+	ParentHash string
+
+	// Address receiving the block reward
+	Miner string
+
+	GasLimit int
+	GasUsed  int
+
+	// Base fee per gas in wei, nil before the London fork
+	BaseFee *big.Int
+
+	Timestamp time.Time
+
+	// Hashes of all transactions mined in this block
+	TransactionHashes []string
+
+	// All transactions mined in this block, if requested
 	Transactions []*Transaction
 }
 
@@ -57,6 +109,182 @@ type BlockUncle struct {
 	BlockReward   *big.Int
 }
 
+// MinedBlockType selects whether MinedBlocks lists blocks or uncles
+type MinedBlockType string
+
+const (
+	MinedBlockTypeBlocks MinedBlockType = "blocks"
+	MinedBlockTypeUncles MinedBlockType = "uncles"
+)
+
+// Response with list of blocks mined by an address
+type minedBlocksResponse struct {
+	*baseResponse
+	Result json.RawMessage `json:"result"`
+}
+
+// Unparsed mined block
+type minedBlockResponse struct {
+	BlockNumber string `json:"blockNumber"`
+	TimeStamp   string `json:"timeStamp"`
+	BlockReward string `json:"blockReward"`
+}
+
+// MinedBlock is a block or uncle mined by an address
+type MinedBlock struct {
+	BlockNumber int
+	TimeStamp   time.Time
+	// Reward paid to the miner in wei
+	BlockReward *big.Int
+}
+
+func parseMinedBlocksResponse(r io.Reader) ([]*MinedBlock, error) {
+	res := minedBlocksResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	var results []*minedBlockResponse
+	if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+		// An address that never mined returns an error status with an empty
+		// list
+		if json.Unmarshal(res.Result, &results) == nil && len(results) == 0 {
+			return []*MinedBlock{}, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(res.Result, &results); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*MinedBlock, len(results))
+	for i, b := range results {
+		blocks[i] = &MinedBlock{
+			BlockNumber: parseInt(b.BlockNumber),
+			TimeStamp:   time.Unix(int64(parseInt(b.TimeStamp)), 0),
+			BlockReward: parseBig(b.BlockReward),
+		}
+	}
+	return blocks, nil
+}
+
+// Closest selects which block BlockNumberByTime returns when no block was
+// mined at exactly the given time
+type Closest string
+
+const (
+	// Last block mined before the given time
+	ClosestBefore Closest = "before"
+	// First block mined after the given time
+	ClosestAfter Closest = "after"
+)
+
+// BlockRange is an inclusive range of block numbers
+type BlockRange struct {
+	StartBlock int
+	EndBlock   int
+}
+
+// ApplyEventLogOptions restricts the event log options to the range
+func (r *BlockRange) ApplyEventLogOptions(options *EventLogOptions) {
+	options.FromBlock = r.StartBlock
+	options.ToBlock = r.EndBlock
+}
+
+// ApplyTransactionListOptions restricts the transaction list options to the
+// range
+func (r *BlockRange) ApplyTransactionListOptions(options *TransactionListOptions) {
+	options.StartBlock = r.StartBlock
+	options.EndBlock = r.EndBlock
+}
+
+// Response with a single block number
+type blockNumberResponse struct {
+	*baseResponse
+	BlockNumber string `json:"result"`
+}
+
+func parseBlockNumberResponse(r io.Reader) (int, error) {
+	res := blockNumberResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return 0, err
+	}
+
+	if err := checkResponse(res.baseResponse); err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(res.BlockNumber)
+	if err != nil {
+		return 0, errors.New("Could not parse block number: " + res.BlockNumber)
+	}
+	return n, nil
+}
+
+// Response with the countdown to a future block. The result is an error
+// string instead of an object when the block was already mined
+type blockCountdownResponse struct {
+	*baseResponse
+	Result json.RawMessage `json:"result"`
+}
+
+// Unparsed block countdown
+type blockCountdownResultResponse struct {
+	CurrentBlock      string `json:"CurrentBlock"`
+	CountdownBlock    string `json:"CountdownBlock"`
+	RemainingBlock    string `json:"RemainingBlock"`
+	EstimateTimeInSec string `json:"EstimateTimeInSec"`
+}
+
+// BlockCountdown is the estimated time until a future block is mined
+type BlockCountdown struct {
+	CurrentBlock   int
+	CountdownBlock int
+	RemainingBlock int
+	EstimateTime   time.Duration
+}
+
+// BlockAlreadyMinedError is returned when requesting the countdown to a
+// block that was already mined
+type BlockAlreadyMinedError struct {
+	BlockNumber int
+}
+
+func (e *BlockAlreadyMinedError) Error() string {
+	return fmt.Sprintf("Block %d was already mined", e.BlockNumber)
+}
+
+func parseBlockCountdownResponse(r io.Reader, blockNumber int) (*BlockCountdown, error) {
+	res := blockCountdownResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+		var msg string
+		if json.Unmarshal(res.Result, &msg) == nil && strings.Contains(msg, "already pass") {
+			return nil, &BlockAlreadyMinedError{BlockNumber: blockNumber}
+		}
+		return nil, err
+	}
+
+	countdown := &blockCountdownResultResponse{}
+	if err := json.Unmarshal(res.Result, countdown); err != nil {
+		return nil, err
+	}
+	seconds, err := strconv.ParseFloat(countdown.EstimateTimeInSec, 64)
+	if err != nil {
+		return nil, errors.New("Could not parse estimated time: " + countdown.EstimateTimeInSec)
+	}
+
+	return &BlockCountdown{
+		CurrentBlock:   parseInt(countdown.CurrentBlock),
+		CountdownBlock: parseInt(countdown.CountdownBlock),
+		RemainingBlock: parseInt(countdown.RemainingBlock),
+		EstimateTime:   time.Duration(seconds * float64(time.Second)),
+	}, nil
+}
+
 func parseBlockRewardResponse(r io.Reader) (*BlockReward, error) {
 	res := blockResponse{baseResponse: &baseResponse{}}
 	if err := json.NewDecoder(r).Decode(&res); err != nil {
@@ -110,6 +338,49 @@ func (c *Client) buildBlockRequest(blockNumber int) (*http.Request, error) {
 	return c.buildRequest(params)
 }
 
+func (c *Client) buildMinedBlocksRequest(addr string, blockType MinedBlockType, page, offset int) (*http.Request, error) {
+	if !strings.HasPrefix(addr, "0x") {
+		return nil, errors.New("Address must begin with 0x")
+	}
+	if blockType != MinedBlockTypeBlocks && blockType != MinedBlockTypeUncles {
+		return nil, fmt.Errorf("Invalid block type: %s", blockType)
+	}
+	if page <= 0 {
+		return nil, errors.New("page param must >= 1")
+	}
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "getminedblocks")
+	params.Set("address", addr)
+	params.Set("blocktype", string(blockType))
+	params.Set("page", strconv.Itoa(page))
+	params.Set("offset", strconv.Itoa(offset))
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildBlockNumberByTimeRequest(t time.Time, closest Closest) (*http.Request, error) {
+	if closest != ClosestBefore && closest != ClosestAfter {
+		return nil, fmt.Errorf("Invalid closest: %s", closest)
+	}
+	params := url.Values{}
+	params.Set("module", "block")
+	params.Set("action", "getblocknobytime")
+	params.Set("timestamp", strconv.FormatInt(t.Unix(), 10))
+	params.Set("closest", string(closest))
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildBlockCountdownRequest(blockNumber int) (*http.Request, error) {
+	params := url.Values{}
+	params.Set("module", "block")
+	params.Set("action", "getblockcountdown")
+	params.Set("blockno", strconv.Itoa(blockNumber))
+
+	return c.buildRequest(params)
+}
+
 func (c *Client) blockReward(ctx context.Context, blockNumber int) (*BlockReward, error) {
 	req, err := c.buildBlockRequest(blockNumber)
 	if err != nil {
@@ -132,3 +403,106 @@ func (c *Client) BlockReward(blockNumber int) (*BlockReward, error) {
 func (c *Client) BlockRewardContext(ctx context.Context, blockNumber int) (*BlockReward, error) {
 	return c.blockReward(ctx, blockNumber)
 }
+
+func (c *Client) minedBlocks(ctx context.Context, addr string, blockType MinedBlockType, page, offset int) ([]*MinedBlock, error) {
+	req, err := c.buildMinedBlocksRequest(addr, blockType, page, offset)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseMinedBlocksResponse(resp.Body)
+}
+
+// MinedBlocks returns a list of blocks or uncles mined by the given address
+func (c *Client) MinedBlocks(addr string, blockType MinedBlockType, page, offset int) ([]*MinedBlock, error) {
+	return c.minedBlocks(context.Background(), addr, blockType, page, offset)
+}
+
+// MinedBlocksContext returns a list of blocks or uncles mined by the given
+// address with a custom context
+func (c *Client) MinedBlocksContext(ctx context.Context, addr string, blockType MinedBlockType, page, offset int) ([]*MinedBlock, error) {
+	return c.minedBlocks(ctx, addr, blockType, page, offset)
+}
+
+func (c *Client) blockNumberByTime(ctx context.Context, t time.Time, closest Closest) (int, error) {
+	req, err := c.buildBlockNumberByTimeRequest(t, closest)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return parseBlockNumberResponse(resp.Body)
+}
+
+func (c *Client) blockRangeByTime(ctx context.Context, start, end time.Time) (*BlockRange, error) {
+	if end.Before(start) {
+		return nil, errors.New("End time must not be before start time")
+	}
+	startBlock, err := c.blockNumberByTime(ctx, start, ClosestAfter)
+	if err != nil {
+		return nil, err
+	}
+	endBlock, err := c.blockNumberByTime(ctx, end, ClosestBefore)
+	if err != nil {
+		return nil, err
+	}
+	if endBlock < startBlock {
+		return nil, fmt.Errorf("No blocks mined between %s and %s", start, end)
+	}
+	return &BlockRange{StartBlock: startBlock, EndBlock: endBlock}, nil
+}
+
+// BlockNumberByTime returns the number of the block mined closest to the
+// given time, before or after it
+func (c *Client) BlockNumberByTime(t time.Time, closest Closest) (int, error) {
+	return c.blockNumberByTime(context.Background(), t, closest)
+}
+
+// BlockNumberByTimeContext returns the number of the block mined closest to
+// the given time, before or after it, with a custom context
+func (c *Client) BlockNumberByTimeContext(ctx context.Context, t time.Time, closest Closest) (int, error) {
+	return c.blockNumberByTime(ctx, t, closest)
+}
+
+// BlockRangeByTime returns the range of blocks mined between start and end
+func (c *Client) BlockRangeByTime(start, end time.Time) (*BlockRange, error) {
+	return c.blockRangeByTime(context.Background(), start, end)
+}
+
+// BlockRangeByTimeContext returns the range of blocks mined between start
+// and end with a custom context
+func (c *Client) BlockRangeByTimeContext(ctx context.Context, start, end time.Time) (*BlockRange, error) {
+	return c.blockRangeByTime(ctx, start, end)
+}
+
+func (c *Client) blockCountdown(ctx context.Context, blockNumber int) (*BlockCountdown, error) {
+	req, err := c.buildBlockCountdownRequest(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseBlockCountdownResponse(resp.Body, blockNumber)
+}
+
+// BlockCountdown returns the estimated time until the given block is mined.
+// It returns a *BlockAlreadyMinedError if the block was already mined
+func (c *Client) BlockCountdown(blockNumber int) (*BlockCountdown, error) {
+	return c.blockCountdown(context.Background(), blockNumber)
+}
+
+// BlockCountdownContext returns the estimated time until the given block is
+// mined with a custom context
+func (c *Client) BlockCountdownContext(ctx context.Context, blockNumber int) (*BlockCountdown, error) {
+	return c.blockCountdown(ctx, blockNumber)
+}
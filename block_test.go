@@ -2,7 +2,9 @@ package etherscan
 
 import (
 	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,3 +39,100 @@ func TestBlock(t *testing.T) {
 	val.SetString("3750000000000000001", 10)
 	assert.EqualValues(val, block.Uncles[1].BlockReward)
 }
+
+func TestBlockTag(t *testing.T) {
+	assert := assert.New(t)
+
+	for tag, expected := range map[BlockTag]string{
+		"":                     "latest",
+		BlockTagLatest:         "latest",
+		BlockTagPending:        "pending",
+		BlockTagEarliest:       "earliest",
+		BlockNumberTag(379224): "0x5c958",
+	} {
+		param, err := tag.param()
+		assert.NoError(err)
+		assert.Equal(expected, param)
+	}
+
+	_, err := BlockTag("379224").param()
+	assert.Error(err)
+	_, err = BlockTag("0xzz").param()
+	assert.Error(err)
+}
+
+func TestMinedBlocks(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "mined_blocks.json")
+
+	blocks, err := parseMinedBlocksResponse(r)
+	assert.NoError(err)
+	assert.Len(blocks, 2)
+
+	assert.Equal(3462296, blocks[0].BlockNumber)
+	assert.EqualValues(time.Unix(1491118514, 0), blocks[0].TimeStamp)
+	val := &big.Int{}
+	val.SetString("5194770940000000000", 10)
+	assert.EqualValues(val, blocks[0].BlockReward)
+
+	blocks, err = parseMinedBlocksResponse(strings.NewReader(`{"status":"0","message":"No transactions found","result":[]}`))
+	assert.NoError(err)
+	assert.Empty(blocks)
+
+	_, err = parseMinedBlocksResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`))
+	assert.EqualError(err, "API Error: Max rate limit reached")
+}
+
+func TestBlockNumberByTime(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "block_number_by_time.json")
+
+	n, err := parseBlockNumberResponse(r)
+	assert.NoError(err)
+	assert.Equal(9251482, n)
+
+	c := &Client{}
+	req, err := c.buildBlockNumberByTimeRequest(time.Unix(1578638524, 0), ClosestBefore)
+	assert.NoError(err)
+	assert.Equal("1578638524", req.URL.Query().Get("timestamp"))
+	assert.Equal("before", req.URL.Query().Get("closest"))
+
+	_, err = c.buildBlockNumberByTimeRequest(time.Unix(1578638524, 0), "nearest")
+	assert.Error(err)
+}
+
+func TestBlockRange(t *testing.T) {
+	assert := assert.New(t)
+	r := &BlockRange{StartBlock: 100, EndBlock: 200}
+
+	logOptions := &EventLogOptions{}
+	r.ApplyEventLogOptions(logOptions)
+	assert.Equal(100, logOptions.FromBlock)
+	assert.Equal(200, logOptions.ToBlock)
+
+	txOptions := &TransactionListOptions{}
+	r.ApplyTransactionListOptions(txOptions)
+	assert.Equal(100, txOptions.StartBlock)
+	assert.Equal(200, txOptions.EndBlock)
+}
+
+func TestBlockCountdown(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "block_countdown.json")
+
+	countdown, err := parseBlockCountdownResponse(r, 16701588)
+	assert.NoError(err)
+	assert.Equal(12715477, countdown.CurrentBlock)
+	assert.Equal(16701588, countdown.CountdownBlock)
+	assert.Equal(3986111, countdown.RemainingBlock)
+	assert.Equal(52616680*time.Second+500*time.Millisecond, countdown.EstimateTime)
+
+	r = strings.NewReader(`{"status":"0","message":"NOTOK","result":"Error! Block number already pass"}`)
+	_, err = parseBlockCountdownResponse(r, 100)
+	assert.IsType(&BlockAlreadyMinedError{}, err)
+	assert.Equal(100, err.(*BlockAlreadyMinedError).BlockNumber)
+
+	r = strings.NewReader(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`)
+	_, err = parseBlockCountdownResponse(r, 100)
+	assert.EqualError(err, "API Error: Invalid API Key")
+}
@@ -43,25 +43,32 @@ func (c *Client) setDefaults() error {
 	return nil
 }
 
-// Construct a new request to the API that is ready to send
-// All methods use GET requests for now
-func (c *Client) buildRequest(params url.Values) (*http.Request, error) {
+// Checks the required params and sets the API key
+func (c *Client) prepareParams(params url.Values) error {
 	if err := c.setDefaults(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if params == nil {
-		return nil, errors.New("Params are empty")
+		return errors.New("Params are empty")
 	}
 	if params.Get("module") == "" {
-		return nil, errors.New("Missing required parameter: module")
+		return errors.New("Missing required parameter: module")
 	}
 	if params.Get("action") == "" {
-		return nil, errors.New("Missing required parameter: action")
+		return errors.New("Missing required parameter: action")
 	}
 	if params.Get("apikey") == "" {
 		params.Set("apikey", c.APIKey)
 	}
+	return nil
+}
+
+// Construct a new GET request to the API that is ready to send
+func (c *Client) buildRequest(params url.Values) (*http.Request, error) {
+	if err := c.prepareParams(params); err != nil {
+		return nil, err
+	}
 
 	reqURL := c.apiBase + "?" + params.Encode()
 	req, err := http.NewRequest("GET", reqURL, nil)
@@ -72,6 +79,22 @@ func (c *Client) buildRequest(params url.Values) (*http.Request, error) {
 	return req, nil
 }
 
+// Construct a new POST request to the API that is ready to send. Params are
+// sent as a form in the body, for payloads too large for a URL
+func (c *Client) buildPostRequest(params url.Values) (*http.Request, error) {
+	if err := c.prepareParams(params); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.apiBase, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
 // Sends a request and returns the response body
 func (c *Client) sendRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	if ctx == nil {
@@ -83,3 +106,17 @@ func (c *Client) sendRequest(ctx context.Context, req *http.Request) (*http.Resp
 	req = req.WithContext(ctx)
 	return c.HTTPClient.Do(req)
 }
+
+// Splits addresses into chunks of at most size, for actions that limit how
+// many addresses a single request may take
+func chunkAddresses(addrs []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(addrs); start += size {
+		end := start + size
+		if end > len(addrs) {
+			end = len(addrs)
+		}
+		chunks = append(chunks, addrs[start:end])
+	}
+	return chunks
+}
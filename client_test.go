@@ -4,15 +4,51 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// Serves canned responses instead of calling the API
+type fakeTransport struct {
+	// Body of every response, unless handle is set
+	body string
+	// Builds the body of the response to a request
+	handle func(req *http.Request) (string, error)
+	// Last request made
+	req *http.Request
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.req = req
+	body := f.body
+	if f.handle != nil {
+		var err error
+		if body, err = f.handle(req); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+// Returns a client served by the fake transport
+func newFakeClient(f *fakeTransport) *Client {
+	return &Client{
+		APIKey:     "test123",
+		HTTPClient: &http.Client{Transport: f},
+	}
+}
+
 // Loads fixtures from testdata directory
 func loadTestData(t *testing.T, name string) io.Reader {
 	path := filepath.Join("testdata", name) // relative path
@@ -96,3 +132,34 @@ func ExampleClient_context() {
 
 	fmt.Print(balance, err)
 }
+
+func TestBuildPostRequest(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{
+		APIKey: "test123",
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "verifysourcecode")
+	params.Set("sourceCode", "contract A {}")
+
+	req, err := c.buildPostRequest(params)
+	assert.NoError(err)
+	assert.Equal("POST", req.Method)
+	assert.Equal(apiEndpoints["mainnet"], req.URL.String())
+	assert.Equal("application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
+	assert.NoError(req.ParseForm())
+	assert.Equal("verifysourcecode", req.PostForm.Get("action"))
+	assert.Equal("test123", req.PostForm.Get("apikey"))
+	assert.Equal("contract A {}", req.PostForm.Get("sourceCode"))
+}
+
+func TestChunkAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	addrs := []string{"0x1", "0x2", "0x3", "0x4", "0x5", "0x6", "0x7"}
+	assert.Equal([][]string{{"0x1", "0x2", "0x3"}, {"0x4", "0x5", "0x6"}, {"0x7"}}, chunkAddresses(addrs, 3))
+	assert.Equal([][]string{addrs}, chunkAddresses(addrs, balanceMultiLimit))
+	assert.Empty(chunkAddresses(nil, 3))
+}
@@ -4,12 +4,188 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Maximum number of addresses accepted by a single getcontractcreation
+// request
+const contractCreationLimit = 5
+
+// ErrContractNotVerified is returned when requesting the source code of a
+// contract that is not verified on Etherscan
+var ErrContractNotVerified = errors.New("Contract source code not verified")
+
+// CodeFormat is the format of source code submitted for verification
+type CodeFormat string
+
+const (
+	// A single, flattened Solidity file
+	CodeFormatSingleFile CodeFormat = "solidity-single-file"
+	// Solidity standard JSON compiler input
+	CodeFormatStandardJSON CodeFormat = "solidity-standard-json-input"
+)
+
+// SourceCodeVerification is the source code and compiler settings of a
+// deployed contract, submitted to Etherscan for verification
+type SourceCodeVerification struct {
+	ContractAddress string
+
+	// Default: CodeFormatSingleFile
+	CodeFormat CodeFormat
+
+	// Source code, or the JSON encoded compiler input for
+	// CodeFormatStandardJSON
+	SourceCode string
+
+	// Name of the contract. For standard JSON input, prefixed with the path
+	// of its file, such as "contracts/Token.sol:Token"
+	ContractName string
+
+	// Full compiler version, such as "v0.8.4+commit.c7e474f2"
+	CompilerVersion string
+
+	// Optimizer settings, ignored for standard JSON input
+	OptimizationUsed bool
+	Runs             int
+
+	// Constructor arguments, ABI encoded as hex
+	ConstructorArguments string
+
+	// Default: the compiler default
+	EVMVersion string
+
+	// License type number as listed on Etherscan, such as 3 for MIT
+	LicenseType int
+}
+
+// VerificationState is the state of a source code verification
+type VerificationState string
+
+const (
+	VerificationPending VerificationState = "pending"
+	VerificationPass    VerificationState = "pass"
+	VerificationFail    VerificationState = "fail"
+)
+
+// VerificationStatus is the result of checking a source code verification
+type VerificationStatus struct {
+	GUID  string
+	State VerificationState
+	// Message from Etherscan, such as "Fail - Unable to verify"
+	Message string
+}
+
+// ProxyVerificationStatus is the result of checking a proxy contract
+// verification
+type ProxyVerificationStatus struct {
+	GUID  string
+	State VerificationState
+	// Address of the implementation contract detected for the proxy
+	Implementation string
+	Message        string
+}
+
+// Finds the implementation address in a successful proxy verification
+// message
+var proxyImplementationPattern = regexp.MustCompile(`implementation contract is found at (0x[0-9a-fA-F]{40}) and is successfully updated`)
+
+// Response with a single string result, such as a verification GUID
+type stringResponse struct {
+	*baseResponse
+	Result string `json:"result"`
+}
+
+// Parses a response with a string result. Errors are reported in the result
+// rather than the message
+func parseStringResponse(r io.Reader) (string, error) {
+	res := &stringResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return "", err
+	}
+	if err := checkResponse(res.baseResponse); err != nil {
+		if res.Result != "" {
+			return "", errors.New("API Error: " + res.Result)
+		}
+		return "", err
+	}
+	return res.Result, nil
+}
+
+// Results of checkverifystatus for a verified contract
+const (
+	verificationPassMessage            = "Pass - Verified"
+	verificationAlreadyVerifiedMessage = "Already Verified"
+)
+
+// Parses a checkverifystatus response. Pending and failed verifications are
+// reported with an error status, but are not errors
+func parseVerificationStatusResponse(r io.Reader, guid string) (*VerificationStatus, error) {
+	res := &stringResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	status := &VerificationStatus{GUID: guid, Message: res.Result}
+	switch {
+	case res.Result == verificationPassMessage, res.Result == verificationAlreadyVerifiedMessage:
+		status.State = VerificationPass
+	case strings.HasPrefix(res.Result, "Pending"), strings.HasPrefix(res.Result, "In progress"):
+		status.State = VerificationPending
+	case strings.HasPrefix(res.Result, "Fail"):
+		status.State = VerificationFail
+	default:
+		if err := checkResponse(res.baseResponse); err != nil {
+			return nil, errors.New("API Error: " + res.Result)
+		}
+		// Never report an unknown result as verified
+		return nil, errors.New("Unknown verification status: " + res.Result)
+	}
+	return status, nil
+}
+
+// Parses a checkproxyverification response
+func parseProxyVerificationResponse(r io.Reader, guid string) (*ProxyVerificationStatus, error) {
+	res := &stringResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	status := &ProxyVerificationStatus{GUID: guid, Message: res.Result}
+	if m := proxyImplementationPattern.FindStringSubmatch(res.Result); m != nil {
+		status.State = VerificationPass
+		status.Implementation = m[1]
+		return status, nil
+	}
+	switch {
+	case strings.HasPrefix(res.Result, "Pending"), strings.HasPrefix(res.Result, "In progress"):
+		status.State = VerificationPending
+	case strings.Contains(res.Result, "not detected"):
+		status.State = VerificationFail
+	default:
+		if err := checkResponse(res.baseResponse); err != nil {
+			return nil, errors.New("API Error: " + res.Result)
+		}
+		// Never report an unknown result as verified
+		return nil, errors.New("Unknown proxy verification status: " + res.Result)
+	}
+	return status, nil
+}
+
+// Checks that the address is a hex address
+func validateAddress(addr string) error {
+	if !strings.HasPrefix(addr, "0x") {
+		return errors.New("Address must begin with 0x")
+	}
+	return nil
+}
+
 // Response with balance of a single address
 type abiResponse struct {
 	*baseResponse
@@ -28,9 +204,200 @@ func parseABIResponse(r io.Reader) ([]byte, error) {
 	return res.Data, nil
 }
 
+// Response with the source code of a contract
+type sourceCodeResponse struct {
+	*baseResponse
+	Sources []*sourceCodeResultResponse `json:"result"`
+}
+
+// Unparsed contract source code
+type sourceCodeResultResponse struct {
+	SourceCode           string `json:"SourceCode"`
+	ABI                  string `json:"ABI"`
+	ContractName         string `json:"ContractName"`
+	CompilerVersion      string `json:"CompilerVersion"`
+	OptimizationUsed     string `json:"OptimizationUsed"`
+	Runs                 string `json:"Runs"`
+	ConstructorArguments string `json:"ConstructorArguments"`
+	EVMVersion           string `json:"EVMVersion"`
+	Library              string `json:"Library"`
+	LicenseType          string `json:"LicenseType"`
+	Proxy                string `json:"Proxy"`
+	Implementation       string `json:"Implementation"`
+	SwarmSource          string `json:"SwarmSource"`
+}
+
+// Solidity standard JSON compiler input, as embedded in source code
+type standardJSONInput struct {
+	Language string `json:"language"`
+	Sources  map[string]struct {
+		Content string `json:"content"`
+	} `json:"sources"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+// ContractSource is the verified source code of a contract and the settings
+// it was compiled with
+type ContractSource struct {
+	ContractName string
+
+	// Source files keyed by path. A single file source is keyed by the
+	// contract name
+	Sources map[string]string
+
+	// Source code as returned by the API, which for multi-file sources is
+	// the JSON encoded compiler input
+	SourceCode string
+
+	// Source language for standard JSON input, such as "Solidity"
+	Language string
+
+	// Compiler settings for standard JSON input, unparsed
+	Settings json.RawMessage
+
+	// Raw, unparsed ABI definition
+	ABI []byte
+
+	CompilerVersion  string
+	OptimizationUsed bool
+	// Number of optimizer runs
+	Runs int
+
+	// Constructor arguments, ABI encoded as hex without the 0x prefix
+	ConstructorArguments string
+
+	EVMVersion  string
+	Library     string
+	License     string
+	SwarmSource string
+
+	// Whether Etherscan detected this contract as a proxy, and the address of
+	// its implementation
+	Proxy          bool
+	Implementation string
+}
+
+// Parses a source code response for a single contract
+func parseSourceCodeResponse(r io.Reader) (*ContractSource, error) {
+	res := &sourceCodeResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+	if err := checkResponse(res.baseResponse); err != nil {
+		return nil, err
+	}
+	if len(res.Sources) == 0 {
+		return nil, errors.New("result is empty")
+	}
+
+	src := res.Sources[0]
+	if src.SourceCode == "" {
+		return nil, ErrContractNotVerified
+	}
+	source := &ContractSource{
+		ContractName:         src.ContractName,
+		SourceCode:           src.SourceCode,
+		ABI:                  []byte(src.ABI),
+		CompilerVersion:      src.CompilerVersion,
+		OptimizationUsed:     parseBool(src.OptimizationUsed),
+		Runs:                 parseInt(src.Runs),
+		ConstructorArguments: src.ConstructorArguments,
+		EVMVersion:           src.EVMVersion,
+		Library:              src.Library,
+		License:              src.LicenseType,
+		SwarmSource:          src.SwarmSource,
+		Proxy:                parseBool(src.Proxy),
+		Implementation:       src.Implementation,
+	}
+	if err := parseSourceFiles(source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// Splits the source code into files. Standard JSON input is wrapped in an
+// extra pair of braces by the API, while older multi-file sources are a JSON
+// object of file path to content
+func parseSourceFiles(source *ContractSource) error {
+	code := strings.TrimSpace(source.SourceCode)
+	if !strings.HasPrefix(code, "{") {
+		source.Sources = map[string]string{source.ContractName: source.SourceCode}
+		return nil
+	}
+	if strings.HasPrefix(code, "{{") && strings.HasSuffix(code, "}}") {
+		code = code[1 : len(code)-1]
+	}
+
+	input := &standardJSONInput{}
+	if err := json.Unmarshal([]byte(code), input); err != nil {
+		return errors.New("Could not parse source code: " + err.Error())
+	}
+	if input.Sources == nil {
+		// Not standard JSON input, files are at the top level
+		if err := json.Unmarshal([]byte(code), &input.Sources); err != nil {
+			return errors.New("Could not parse source code: " + err.Error())
+		}
+	}
+
+	source.Language = input.Language
+	source.Settings = input.Settings
+	source.Sources = make(map[string]string, len(input.Sources))
+	for path, file := range input.Sources {
+		source.Sources[path] = file.Content
+	}
+	return nil
+}
+
+// Response with the creators of several contracts
+type contractCreationResponse struct {
+	*baseResponse
+	Result json.RawMessage `json:"result"`
+}
+
+// Unparsed contract creation
+type contractCreationResultResponse struct {
+	ContractAddress string `json:"contractAddress"`
+	ContractCreator string `json:"contractCreator"`
+	TxHash          string `json:"txHash"`
+}
+
+// ContractCreation identifies who deployed a contract and in which
+// transaction
+type ContractCreation struct {
+	ContractAddress string
+	// Address of the account or contract that deployed the contract
+	Creator string
+	// Hash of the transaction that created the contract
+	TxHash string
+}
+
+func parseContractCreationResponse(r io.Reader) ([]*ContractCreation, error) {
+	res := &contractCreationResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+	if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+		return nil, err
+	}
+	var results []*contractCreationResultResponse
+	if err := json.Unmarshal(res.Result, &results); err != nil {
+		return nil, err
+	}
+
+	creations := make([]*ContractCreation, len(results))
+	for i, c := range results {
+		creations[i] = &ContractCreation{
+			ContractAddress: c.ContractAddress,
+			Creator:         c.ContractCreator,
+			TxHash:          c.TxHash,
+		}
+	}
+	return creations, nil
+}
+
 func (c *Client) buildContractABIRequest(addr string) (*http.Request, error) {
-	if !strings.HasPrefix(addr, "0x") {
-		return nil, errors.New("Address must begin with 0x")
+	if err := validateAddress(addr); err != nil {
+		return nil, err
 	}
 	params := url.Values{}
 	params.Set("module", "contract")
@@ -40,6 +407,127 @@ func (c *Client) buildContractABIRequest(addr string) (*http.Request, error) {
 	return c.buildRequest(params)
 }
 
+func (c *Client) buildContractSourceRequest(addr string) (*http.Request, error) {
+	if err := validateAddress(addr); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "getsourcecode")
+	params.Set("address", addr)
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildContractCreationRequest(addrs []string) (*http.Request, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("At least one address is required")
+	}
+	if len(addrs) > contractCreationLimit {
+		return nil, fmt.Errorf("At most %d addresses are allowed per request", contractCreationLimit)
+	}
+	for _, addr := range addrs {
+		if err := validateAddress(addr); err != nil {
+			return nil, err
+		}
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "getcontractcreation")
+	params.Set("contractaddresses", strings.Join(addrs, ","))
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildVerifySourceCodeRequest(v SourceCodeVerification) (*http.Request, error) {
+	if err := validateAddress(v.ContractAddress); err != nil {
+		return nil, err
+	}
+	if v.SourceCode == "" {
+		return nil, errors.New("Source code is required")
+	}
+	if v.ContractName == "" {
+		return nil, errors.New("Contract name is required")
+	}
+	if v.CompilerVersion == "" {
+		return nil, errors.New("Compiler version is required")
+	}
+	if v.CodeFormat == "" {
+		v.CodeFormat = CodeFormatSingleFile
+	}
+	if v.CodeFormat != CodeFormatSingleFile && v.CodeFormat != CodeFormatStandardJSON {
+		return nil, fmt.Errorf("Invalid code format: %s", v.CodeFormat)
+	}
+
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "verifysourcecode")
+	params.Set("contractaddress", v.ContractAddress)
+	params.Set("sourceCode", v.SourceCode)
+	params.Set("codeformat", string(v.CodeFormat))
+	params.Set("contractname", v.ContractName)
+	params.Set("compilerversion", v.CompilerVersion)
+	if v.CodeFormat == CodeFormatSingleFile {
+		params.Set("optimizationUsed", "0")
+		if v.OptimizationUsed {
+			params.Set("optimizationUsed", "1")
+		}
+		params.Set("runs", strconv.Itoa(v.Runs))
+	}
+	// Misspelled by the API
+	params.Set("constructorArguements", strings.TrimPrefix(v.ConstructorArguments, "0x"))
+	if v.EVMVersion != "" {
+		params.Set("evmversion", v.EVMVersion)
+	}
+	if v.LicenseType != 0 {
+		params.Set("licenseType", strconv.Itoa(v.LicenseType))
+	}
+
+	return c.buildPostRequest(params)
+}
+
+func (c *Client) buildCheckVerifyStatusRequest(guid string) (*http.Request, error) {
+	if guid == "" {
+		return nil, errors.New("GUID is required")
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "checkverifystatus")
+	params.Set("guid", guid)
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildVerifyProxyContractRequest(addr, expectedImpl string) (*http.Request, error) {
+	if err := validateAddress(addr); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "verifyproxycontract")
+	params.Set("address", addr)
+	if expectedImpl != "" {
+		if err := validateAddress(expectedImpl); err != nil {
+			return nil, err
+		}
+		params.Set("expectedimplementation", expectedImpl)
+	}
+
+	return c.buildPostRequest(params)
+}
+
+func (c *Client) buildCheckProxyVerificationRequest(guid string) (*http.Request, error) {
+	if guid == "" {
+		return nil, errors.New("GUID is required")
+	}
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "checkproxyverification")
+	params.Set("guid", guid)
+
+	return c.buildRequest(params)
+}
+
 func (c *Client) contractABI(ctx context.Context, addr string) ([]byte, error) {
 	req, err := c.buildContractABIRequest(addr)
 	if err != nil {
@@ -64,3 +552,196 @@ func (c *Client) ContractABI(addr string) ([]byte, error) {
 func (c *Client) ContractABIContext(ctx context.Context, addr string) ([]byte, error) {
 	return c.contractABI(ctx, addr)
 }
+
+func (c *Client) contractSource(ctx context.Context, addr string) (*ContractSource, error) {
+	req, err := c.buildContractSourceRequest(addr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseSourceCodeResponse(resp.Body)
+}
+
+// ContractSource returns the verified source code and compiler settings of a
+// smart contract at the given address. It returns ErrContractNotVerified if
+// the contract is not verified
+func (c *Client) ContractSource(addr string) (*ContractSource, error) {
+	return c.contractSource(context.Background(), addr)
+}
+
+// ContractSourceContext returns the verified source code and compiler
+// settings of a smart contract at the given address with a custom context
+func (c *Client) ContractSourceContext(ctx context.Context, addr string) (*ContractSource, error) {
+	return c.contractSource(ctx, addr)
+}
+
+func (c *Client) contractCreation(ctx context.Context, addrs []string) ([]*ContractCreation, error) {
+	var creations []*ContractCreation
+	for _, chunk := range chunkAddresses(addrs, contractCreationLimit) {
+		req, err := c.buildContractCreationRequest(chunk)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.sendRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		chunkCreations, err := parseContractCreationResponse(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		creations = append(creations, chunkCreations...)
+	}
+	return creations, nil
+}
+
+// ContractCreation returns the creator and creation transaction of the
+// contracts at the given addresses. Addresses are sent in batches of 5, the
+// maximum allowed by the API
+func (c *Client) ContractCreation(addrs ...string) ([]*ContractCreation, error) {
+	return c.contractCreation(context.Background(), addrs)
+}
+
+// ContractCreationContext returns the creator and creation transaction of
+// the contracts at the given addresses with a custom context
+func (c *Client) ContractCreationContext(ctx context.Context, addrs ...string) ([]*ContractCreation, error) {
+	return c.contractCreation(ctx, addrs)
+}
+
+func (c *Client) verifySourceCode(ctx context.Context, v SourceCodeVerification) (string, error) {
+	req, err := c.buildVerifySourceCodeRequest(v)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return parseStringResponse(resp.Body)
+}
+
+func (c *Client) checkVerifyStatus(ctx context.Context, guid string) (*VerificationStatus, error) {
+	req, err := c.buildCheckVerifyStatusRequest(guid)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseVerificationStatusResponse(resp.Body, guid)
+}
+
+func (c *Client) waitForVerification(ctx context.Context, guid string) (*VerificationStatus, error) {
+	ticker := time.NewTicker(verifyPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := c.checkVerifyStatus(ctx, guid)
+		if err != nil {
+			return nil, err
+		}
+		if status.State != VerificationPending {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// VerifySourceCode submits the source code of a deployed contract for
+// verification and returns the GUID used to check its status
+func (c *Client) VerifySourceCode(v SourceCodeVerification) (string, error) {
+	return c.verifySourceCode(context.Background(), v)
+}
+
+// VerifySourceCodeContext submits the source code of a deployed contract for
+// verification with a custom context
+func (c *Client) VerifySourceCodeContext(ctx context.Context, v SourceCodeVerification) (string, error) {
+	return c.verifySourceCode(ctx, v)
+}
+
+// CheckVerifyStatus returns the status of a source code verification
+func (c *Client) CheckVerifyStatus(guid string) (*VerificationStatus, error) {
+	return c.checkVerifyStatus(context.Background(), guid)
+}
+
+// CheckVerifyStatusContext returns the status of a source code verification
+// with a custom context
+func (c *Client) CheckVerifyStatusContext(ctx context.Context, guid string) (*VerificationStatus, error) {
+	return c.checkVerifyStatus(ctx, guid)
+}
+
+// WaitForVerification polls the status of a source code verification until
+// it passes or fails. It waits as long as the verification is pending, use
+// WaitForVerificationContext to bound the wait
+func (c *Client) WaitForVerification(guid string) (*VerificationStatus, error) {
+	return c.waitForVerification(context.Background(), guid)
+}
+
+// WaitForVerificationContext polls the status of a source code verification
+// until it passes or fails, or the context is done
+func (c *Client) WaitForVerificationContext(ctx context.Context, guid string) (*VerificationStatus, error) {
+	return c.waitForVerification(ctx, guid)
+}
+
+func (c *Client) verifyProxyContract(ctx context.Context, addr, expectedImpl string) (string, error) {
+	req, err := c.buildVerifyProxyContractRequest(addr, expectedImpl)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return parseStringResponse(resp.Body)
+}
+
+func (c *Client) checkProxyVerification(ctx context.Context, guid string) (*ProxyVerificationStatus, error) {
+	req, err := c.buildCheckProxyVerificationRequest(guid)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyVerificationResponse(resp.Body, guid)
+}
+
+// VerifyProxyContract submits the proxy contract at the given address for
+// verification of its implementation link and returns the GUID used to check
+// its status. If expectedImpl is not empty, verification fails unless the
+// detected implementation matches it
+func (c *Client) VerifyProxyContract(addr, expectedImpl string) (string, error) {
+	return c.verifyProxyContract(context.Background(), addr, expectedImpl)
+}
+
+// VerifyProxyContractContext submits the proxy contract at the given address
+// for verification of its implementation link with a custom context
+func (c *Client) VerifyProxyContractContext(ctx context.Context, addr, expectedImpl string) (string, error) {
+	return c.verifyProxyContract(ctx, addr, expectedImpl)
+}
+
+// CheckProxyVerification returns the status of a proxy contract verification
+// and the detected implementation address
+func (c *Client) CheckProxyVerification(guid string) (*ProxyVerificationStatus, error) {
+	return c.checkProxyVerification(context.Background(), guid)
+}
+
+// CheckProxyVerificationContext returns the status of a proxy contract
+// verification and the detected implementation address with a custom context
+func (c *Client) CheckProxyVerificationContext(ctx context.Context, guid string) (*ProxyVerificationStatus, error) {
+	return c.checkProxyVerification(ctx, guid)
+}
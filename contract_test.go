@@ -1,7 +1,11 @@
 package etherscan
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +18,193 @@ func TestContractABI(t *testing.T) {
 	assert.NotEmpty(rawABI)
 	assert.Contains(string(rawABI), "inputs")
 }
+
+func TestContractSource(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "contract_source.json")
+	source, err := parseSourceCodeResponse(r)
+	assert.NoError(err)
+
+	assert.Equal("Greeter", source.ContractName)
+	assert.Equal("v0.4.18+commit.9cf6e910", source.CompilerVersion)
+	assert.True(source.OptimizationUsed)
+	assert.Equal(200, source.Runs)
+	assert.Equal("None", source.License)
+	assert.False(source.Proxy)
+	assert.Contains(string(source.ABI), "greet")
+	assert.Len(source.Sources, 1)
+	assert.Contains(source.Sources["Greeter"], "contract Greeter")
+}
+
+func TestContractSourceMultiFile(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "contract_source_multi.json")
+	source, err := parseSourceCodeResponse(r)
+	assert.NoError(err)
+
+	assert.Equal("Solidity", source.Language)
+	assert.Contains(string(source.Settings), "optimizer")
+	assert.Len(source.Sources, 2)
+	assert.Contains(source.Sources["contracts/Storage.sol"], "contract Storage")
+	assert.Equal("000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", source.ConstructorArguments)
+	assert.True(source.Proxy)
+	assert.Equal("0xa2327a938febf5fec13bacfb16ae10ecbc4cbdcf", source.Implementation)
+}
+
+func TestContractSourceNotVerified(t *testing.T) {
+	r := strings.NewReader(`{"status":"1","message":"OK","result":[{"SourceCode":"","ABI":"Contract source code not verified","ContractName":""}]}`)
+	_, err := parseSourceCodeResponse(r)
+	assert.Equal(t, ErrContractNotVerified, err)
+}
+
+func TestContractCreation(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "contract_creation.json")
+	creations, err := parseContractCreationResponse(r)
+	assert.NoError(err)
+	assert.Len(creations, 2)
+
+	assert.Equal("0xb83c27805aaca5c7082eb45c868d955cf04c337f", creations[0].ContractAddress)
+	assert.Equal("0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45", creations[0].Creator)
+	assert.Equal("0x5d5d4218a6a2a1c18e6bbfd0b3a1d4d4d0a7d4a0d0cd14faf3d8a2e3b3e3c8ff", creations[0].TxHash)
+
+	c := &Client{}
+	_, err = c.buildContractCreationRequest([]string{"0x1", "0x2", "0x3", "0x4", "0x5", "0x6"})
+	assert.Error(err)
+	_, err = c.buildContractCreationRequest([]string{"b83c27805aaca5c7082eb45c868d955cf04c337f"})
+	assert.Error(err)
+
+	_, err = parseContractCreationResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+	assert.EqualError(err, "API Error: Invalid API Key")
+}
+
+func TestVerifySourceCode(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{}
+	req, err := c.buildVerifySourceCodeRequest(SourceCodeVerification{
+		ContractAddress:      "0x9bc1ef3e3a0bb8d5e6e6c8c4d3b2c6e2a1f0e9d8",
+		SourceCode:           "pragma solidity ^0.8.0; contract Token {}",
+		ContractName:         "Token",
+		CompilerVersion:      "v0.8.4+commit.c7e474f2",
+		OptimizationUsed:     true,
+		Runs:                 200,
+		ConstructorArguments: "0x0000000000000000000000000000000000000000000000000000000000000001",
+	})
+	assert.NoError(err)
+	assert.Equal("POST", req.Method)
+	assert.NoError(req.ParseForm())
+	assert.Equal("solidity-single-file", req.PostForm.Get("codeformat"))
+	assert.Equal("1", req.PostForm.Get("optimizationUsed"))
+	assert.Equal("200", req.PostForm.Get("runs"))
+	assert.Equal("0000000000000000000000000000000000000000000000000000000000000001", req.PostForm.Get("constructorArguements"))
+
+	guid, err := parseStringResponse(strings.NewReader(`{"status":"1","message":"OK","result":"ezq878u486pzijkvvmerl6a9mzwhv6sefgvqi5tkwceejc7tvn"}`))
+	assert.NoError(err)
+	assert.Equal("ezq878u486pzijkvvmerl6a9mzwhv6sefgvqi5tkwceejc7tvn", guid)
+
+	_, err = parseStringResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Contract source code already verified"}`))
+	assert.EqualError(err, "API Error: Contract source code already verified")
+}
+
+func TestVerificationStatus(t *testing.T) {
+	assert := assert.New(t)
+	guid := "ezq878u486pzijkvvmerl6a9mzwhv6sefgvqi5tkwceejc7tvn"
+
+	for body, state := range map[string]VerificationState{
+		`{"status":"0","message":"NOTOK","result":"Pending in queue"}`:        VerificationPending,
+		`{"status":"1","message":"OK","result":"Pass - Verified"}`:            VerificationPass,
+		`{"status":"1","message":"OK","result":"Already Verified"}`:           VerificationPass,
+		`{"status":"0","message":"NOTOK","result":"Fail - Unable to verify"}`: VerificationFail,
+	} {
+		status, err := parseVerificationStatusResponse(strings.NewReader(body), guid)
+		assert.NoError(err)
+		assert.Equal(state, status.State)
+		assert.Equal(guid, status.GUID)
+	}
+
+	_, err := parseVerificationStatusResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Unable to locate GUID"}`), guid)
+	assert.EqualError(err, "API Error: Unable to locate GUID")
+
+	_, err = parseVerificationStatusResponse(strings.NewReader(`{"status":"1","message":"OK","result":"Queued for review"}`), guid)
+	assert.EqualError(err, "Unknown verification status: Queued for review")
+}
+
+func TestProxyVerification(t *testing.T) {
+	assert := assert.New(t)
+	guid := "gwgrrnfy56zf6vc1fljuejwg6pelnc5yns6fg6y2i6zfpgzquz"
+
+	r := strings.NewReader(`{"status":"1","message":"OK","result":"The proxy's (0xbc46363a7669f6e12353fa95bb067aead3675c29) implementation contract is found at 0xe45a5176bc0f2c1198e2cf3c6d0b6d3c0b5e2de8 and is successfully updated."}`)
+	status, err := parseProxyVerificationResponse(r, guid)
+	assert.NoError(err)
+	assert.Equal(VerificationPass, status.State)
+	assert.Equal("0xe45a5176bc0f2c1198e2cf3c6d0b6d3c0b5e2de8", status.Implementation)
+
+	r = strings.NewReader(`{"status":"0","message":"NOTOK","result":"A corresponding implementation contract was unfortunately not detected for the proxy address."}`)
+	status, err = parseProxyVerificationResponse(r, guid)
+	assert.NoError(err)
+	assert.Equal(VerificationFail, status.State)
+	assert.Empty(status.Implementation)
+
+	r = strings.NewReader(`{"status":"0","message":"NOTOK","result":"Pending in queue"}`)
+	status, err = parseProxyVerificationResponse(r, guid)
+	assert.NoError(err)
+	assert.Equal(VerificationPending, status.State)
+
+	r = strings.NewReader(`{"status":"1","message":"OK","result":"The proxy's implementation contract is found at 0xe45a5176bc0f2c1198e2cf3c6d0b6d3c0b5e2de8 but could not be updated."}`)
+	_, err = parseProxyVerificationResponse(r, guid)
+	assert.Error(err)
+
+	r = strings.NewReader(`{"status":"1","message":"OK","result":""}`)
+	_, err = parseProxyVerificationResponse(r, guid)
+	assert.Error(err)
+
+	c := &Client{}
+	req, err := c.buildVerifyProxyContractRequest("0xbc46363a7669f6e12353fa95bb067aead3675c29", "0xe45a5176bc0f2c1198e2cf3c6d0b6d3c0b5e2de8")
+	assert.NoError(err)
+	assert.Equal("POST", req.Method)
+	assert.NoError(req.ParseForm())
+	assert.Equal("verifyproxycontract", req.PostForm.Get("action"))
+	assert.Equal("0xe45a5176bc0f2c1198e2cf3c6d0b6d3c0b5e2de8", req.PostForm.Get("expectedimplementation"))
+}
+
+func TestWaitForVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(interval time.Duration) { verifyPollInterval = interval }(verifyPollInterval)
+	verifyPollInterval = time.Millisecond
+
+	guid := "ezq878u486pzijkvvmerl6a9mzwhv6sefgvqi5tkwceejc7tvn"
+	requests := 0
+	c := newFakeClient(&fakeTransport{handle: func(req *http.Request) (string, error) {
+		requests++
+		assert.Equal("checkverifystatus", req.URL.Query().Get("action"))
+		assert.Equal(guid, req.URL.Query().Get("guid"))
+		if requests < 3 {
+			return `{"status":"0","message":"NOTOK","result":"Pending in queue"}`, nil
+		}
+		return `{"status":"1","message":"OK","result":"Pass - Verified"}`, nil
+	}})
+
+	status, err := c.WaitForVerificationContext(context.Background(), guid)
+	assert.NoError(err)
+	assert.Equal(VerificationPass, status.State)
+	assert.Equal(3, requests)
+}
+
+func TestWaitForVerificationCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	// Only cancellation can end the wait
+	defer func(interval time.Duration) { verifyPollInterval = interval }(verifyPollInterval)
+	verifyPollInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := newFakeClient(&fakeTransport{handle: func(req *http.Request) (string, error) {
+		cancel()
+		return `{"status":"0","message":"NOTOK","result":"Pending in queue"}`, nil
+	}})
+
+	_, err := c.WaitForVerificationContext(ctx, "ezq878u486pzijkvvmerl6a9mzwhv6sefgvqi5tkwceejc7tvn")
+	assert.Equal(context.Canceled, err)
+}
@@ -13,6 +13,13 @@ var (
 
 	userAgent     = "go-etherscan"
 	clientTimeout = 30 * time.Second
+
+	// Maximum number of results the API returns for a single query across
+	// all of its pages
+	resultWindow = 10000
+
+	// Delay between checks of a pending source code verification
+	verifyPollInterval = 5 * time.Second
 )
 
 // Returns supported networks based on API endpoints
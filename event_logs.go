@@ -55,23 +55,27 @@ func parseEventLogsResponse(r io.Reader) ([]EventLog, error) {
 
 	logs := make([]EventLog, len(res.Result))
 	for i, l := range res.Result {
-		logs[i] = EventLog{
-			Address:          l.Address,
-			Topics:           l.Topics,
-			Data:             l.Data,
-			BlockNumber:      parseIntFromHex(l.BlockNumber),
-			TimeStamp:        parseIntFromHex(l.TimeStamp),
-			GasPrice:         parseBigFromHex(l.GasPrice),
-			GasUsed:          parseIntFromHex(l.GasUsed),
-			LogIndex:         parseIntFromHex(l.LogIndex),
-			TransactionHash:  l.TransactionHash,
-			TransactionIndex: parseIntFromHex(l.TransactionIndex),
-		}
+		logs[i] = parseEventLog(&l)
 	}
 
 	return logs, nil
 }
 
+func parseEventLog(l *eventLog) EventLog {
+	return EventLog{
+		Address:          l.Address,
+		Topics:           l.Topics,
+		Data:             l.Data,
+		BlockNumber:      parseIntFromHex(l.BlockNumber),
+		TimeStamp:        parseIntFromHex(l.TimeStamp),
+		GasPrice:         parseBigFromHex(l.GasPrice),
+		GasUsed:          parseIntFromHex(l.GasUsed),
+		LogIndex:         parseIntFromHex(l.LogIndex),
+		TransactionHash:  l.TransactionHash,
+		TransactionIndex: parseIntFromHex(l.TransactionIndex),
+	}
+}
+
 type topicOperation string
 
 const (
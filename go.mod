@@ -0,0 +1,15 @@
+module github.com/endpass/etherscan
+
+go 1.17
+
+require (
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/crypto v0.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,907 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Response of the proxy module, which forwards JSON-RPC calls to a node.
+// Errors of the API itself, such as an invalid API key, use the status and
+// message fields of other modules instead
+type proxyResponse struct {
+	*baseResponse
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *RPCError       `json:"error"`
+}
+
+// RPCError is an error returned by the node for a proxy module call
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC Error %d: %s", e.Code, e.Message)
+}
+
+// Errors returned by the node when a raw transaction is rejected. Match them
+// with errors.Is against the error of SendRawTransaction
+var (
+	ErrNonceTooLow            = errors.New("nonce too low")
+	ErrTransactionUnderpriced = errors.New("transaction underpriced")
+	ErrInsufficientFunds      = errors.New("insufficient funds for gas * price + value")
+)
+
+// RevertError is returned when a call or gas estimation reverts
+type RevertError struct {
+	// Message given to require or revert, if any
+	Reason string
+	// Raw revert data, such as an encoded custom error
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
+
+// Selector of Error(string), used by require and revert with a message
+const revertReasonSelector = "08c379a0"
+
+// Parses the revert data and message of a reverted call
+func parseRevertError(data json.RawMessage, msg string) *RevertError {
+	revertErr := &RevertError{}
+	var hexData string
+	if json.Unmarshal(data, &hexData) == nil {
+		revertErr.Data, _ = decodeHex(hexData)
+	}
+	if len(revertErr.Data) >= 4 && hex.EncodeToString(revertErr.Data[:4]) == revertReasonSelector {
+		values, err := decodeABIArguments([]ABIArgument{{Type: "string"}}, revertErr.Data[4:])
+		if err == nil {
+			revertErr.Reason = values[0].(string)
+			return revertErr
+		}
+	}
+	// Some nodes only include the reason in the message
+	if i := strings.Index(msg, "execution reverted: "); i >= 0 {
+		revertErr.Reason = msg[i+len("execution reverted: "):]
+	}
+	return revertErr
+}
+
+// Unwrap returns the known error matching the message of the node, if any.
+// Nodes only report these by message, their codes are not consistent.
+// Reverted calls unwrap to a *RevertError
+func (e *RPCError) Unwrap() error {
+	msg := strings.ToLower(e.Message)
+	switch {
+	case strings.Contains(msg, "execution reverted"):
+		return parseRevertError(e.Data, e.Message)
+	case strings.Contains(msg, "nonce too low"):
+		return ErrNonceTooLow
+	case strings.Contains(msg, "underpriced"):
+		return ErrTransactionUnderpriced
+	case strings.Contains(msg, "insufficient funds"):
+		return ErrInsufficientFunds
+	}
+	return nil
+}
+
+// Unparsed block
+type rpcBlock struct {
+	Number        string `json:"number"`
+	Hash          string `json:"hash"`
+	ParentHash    string `json:"parentHash"`
+	Miner         string `json:"miner"`
+	GasLimit      string `json:"gasLimit"`
+	GasUsed       string `json:"gasUsed"`
+	BaseFeePerGas string `json:"baseFeePerGas"`
+	Timestamp     string `json:"timestamp"`
+	// Hashes, or full transactions
+	Transactions json.RawMessage `json:"transactions"`
+}
+
+// Unparsed transaction. Quantities are hex encoded
+type rpcTransaction struct {
+	BlockHash        string `json:"blockHash"`
+	BlockNumber      string `json:"blockNumber"`
+	From             string `json:"from"`
+	Gas              string `json:"gas"`
+	GasPrice         string `json:"gasPrice"`
+	Hash             string `json:"hash"`
+	Input            string `json:"input"`
+	Nonce            string `json:"nonce"`
+	To               string `json:"to"`
+	TransactionIndex string `json:"transactionIndex"`
+	Value            string `json:"value"`
+
+	Type                 string `json:"type"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+}
+
+// Unparsed transaction receipt
+type rpcReceipt struct {
+	TransactionHash   string      `json:"transactionHash"`
+	TransactionIndex  string      `json:"transactionIndex"`
+	BlockHash         string      `json:"blockHash"`
+	BlockNumber       string      `json:"blockNumber"`
+	From              string      `json:"from"`
+	To                string      `json:"to"`
+	ContractAddress   string      `json:"contractAddress"`
+	CumulativeGasUsed string      `json:"cumulativeGasUsed"`
+	GasUsed           string      `json:"gasUsed"`
+	EffectiveGasPrice string      `json:"effectiveGasPrice"`
+	Status            string      `json:"status"`
+	Type              string      `json:"type"`
+	Logs              []*eventLog `json:"logs"`
+}
+
+// CallMsg is a transaction that is not sent, used to estimate its gas
+type CallMsg struct {
+	From string
+	// Empty for contract creation
+	To string
+	// Value in wei, may be nil
+	Value *big.Int
+	Data  []byte
+	// Gas limit, 0 for no limit
+	Gas int
+}
+
+// Receipt is the result of executing a mined transaction
+type Receipt struct {
+	TransactionHash  string
+	TransactionIndex int
+	BlockHash        string
+	BlockNumber      int
+
+	From string
+	To   string
+
+	// Address of the contract created by the transaction, if any
+	ContractAddress string
+
+	// Gas used by the transaction and all transactions before it in the block
+	CumulativeGasUsed int
+	GasUsed           int
+
+	// Price per gas paid in wei, including the base fee. Nil before the
+	// London fork
+	EffectiveGasPrice *big.Int
+
+	Status ReceiptStatus
+
+	// EIP-2718 transaction type
+	Type int
+
+	// Logs emitted by the transaction
+	Logs []EventLog
+}
+
+// Decodes a proxy response into result. A null result leaves it unchanged
+func parseProxyResponse(r io.Reader, result interface{}) error {
+	res := &proxyResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return err
+	}
+	if res.Status != "" {
+		if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+			return err
+		}
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if len(res.Result) == 0 || string(res.Result) == "null" {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// Parses a hex quantity response such as "0x10d4f"
+func parseProxyIntResponse(r io.Reader) (int, error) {
+	var s string
+	if err := parseProxyResponse(r, &s); err != nil {
+		return 0, err
+	}
+	if s == "" {
+		return 0, errors.New("result is empty")
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return 0, errors.New("Invalid hex quantity: " + s)
+	}
+	n, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, errors.New("Invalid hex quantity: " + s)
+	}
+	return int(n), nil
+}
+
+func parseRPCTransaction(tx *rpcTransaction) *Transaction {
+	parsedTx := &Transaction{
+		Hash:     tx.Hash,
+		Nonce:    parseIntFromHex(tx.Nonce),
+		Index:    parseIntFromHex(tx.TransactionIndex),
+		From:     tx.From,
+		To:       tx.To,
+		Value:    parseBigFromHex(tx.Value),
+		GasLimit: parseIntFromHex(tx.Gas),
+		GasPrice: parseBigFromHex(tx.GasPrice),
+		Data:     tx.Input,
+	}
+	if tx.Type != "" {
+		parsedTx.Type = parseIntFromHex(tx.Type)
+	}
+	if tx.MaxFeePerGas != "" {
+		parsedTx.MaxFeePerGas = parseBigFromHex(tx.MaxFeePerGas)
+		parsedTx.MaxPriorityFeePerGas = parseBigFromHex(tx.MaxPriorityFeePerGas)
+	}
+	// Pending transactions have no block
+	if tx.BlockNumber != "" {
+		parsedTx.Block = &Block{
+			Number: parseIntFromHex(tx.BlockNumber),
+			Hash:   tx.BlockHash,
+		}
+	}
+	return parsedTx
+}
+
+func parseRPCReceipt(r *rpcReceipt) *Receipt {
+	receipt := &Receipt{
+		TransactionHash:   r.TransactionHash,
+		TransactionIndex:  parseIntFromHex(r.TransactionIndex),
+		BlockHash:         r.BlockHash,
+		BlockNumber:       parseIntFromHex(r.BlockNumber),
+		From:              r.From,
+		To:                r.To,
+		ContractAddress:   r.ContractAddress,
+		CumulativeGasUsed: parseIntFromHex(r.CumulativeGasUsed),
+		GasUsed:           parseIntFromHex(r.GasUsed),
+		Status:            parseReceiptStatus(r.Status),
+		Type:              parseIntFromHex(r.Type),
+		Logs:              make([]EventLog, len(r.Logs)),
+	}
+	if r.EffectiveGasPrice != "" {
+		receipt.EffectiveGasPrice = parseBigFromHex(r.EffectiveGasPrice)
+	}
+	for i, l := range r.Logs {
+		receipt.Logs[i] = parseEventLog(l)
+	}
+	return receipt
+}
+
+func parseRPCBlock(b *rpcBlock, fullTx bool) (*Block, error) {
+	block := &Block{
+		Number:     parseIntFromHex(b.Number),
+		Hash:       b.Hash,
+		ParentHash: b.ParentHash,
+		Miner:      b.Miner,
+		GasLimit:   parseIntFromHex(b.GasLimit),
+		GasUsed:    parseIntFromHex(b.GasUsed),
+		Timestamp:  time.Unix(int64(parseIntFromHex(b.Timestamp)), 0),
+	}
+	if b.BaseFeePerGas != "" {
+		block.BaseFee = parseBigFromHex(b.BaseFeePerGas)
+	}
+
+	if len(b.Transactions) == 0 {
+		return block, nil
+	}
+	if !fullTx {
+		if err := json.Unmarshal(b.Transactions, &block.TransactionHashes); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+
+	var txs []*rpcTransaction
+	if err := json.Unmarshal(b.Transactions, &txs); err != nil {
+		return nil, err
+	}
+	block.TransactionHashes = make([]string, len(txs))
+	block.Transactions = make([]*Transaction, len(txs))
+	for i, tx := range txs {
+		block.TransactionHashes[i] = tx.Hash
+		block.Transactions[i] = parseRPCTransaction(tx)
+		block.Transactions[i].Timestamp = block.Timestamp
+	}
+	return block, nil
+}
+
+func parseProxyBlockResponse(r io.Reader, fullTx bool) (*Block, error) {
+	var b *rpcBlock
+	if err := parseProxyResponse(r, &b); err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("Block not found")
+	}
+	return parseRPCBlock(b, fullTx)
+}
+
+func parseProxyTransactionResponse(r io.Reader) (*Transaction, error) {
+	var tx *rpcTransaction
+	if err := parseProxyResponse(r, &tx); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("Transaction not found")
+	}
+	return parseRPCTransaction(tx), nil
+}
+
+func parseProxyReceiptResponse(r io.Reader) (*Receipt, error) {
+	var receipt *rpcReceipt
+	if err := parseProxyResponse(r, &receipt); err != nil {
+		return nil, err
+	}
+	// Pending transactions have no receipt yet
+	if receipt == nil {
+		return nil, errors.New("Receipt not found")
+	}
+	return parseRPCReceipt(receipt), nil
+}
+
+func parseSendRawTransactionResponse(r io.Reader) (string, error) {
+	var hash string
+	if err := parseProxyResponse(r, &hash); err != nil {
+		return "", err
+	}
+	if hash == "" {
+		return "", errors.New("result is empty")
+	}
+	// A hash is 32 bytes of hex with 0x prefix
+	if b, err := decodeHex(hash); err != nil || !strings.HasPrefix(hash, "0x") || len(b) != 32 {
+		return "", errors.New("Invalid transaction hash: " + hash)
+	}
+	return hash, nil
+}
+
+// Parses a hex quantity response that may not fit an int, such as a price
+func parseProxyBigResponse(r io.Reader) (*big.Int, error) {
+	var s string
+	if err := parseProxyResponse(r, &s); err != nil {
+		return nil, err
+	}
+	if s == "" {
+		return nil, errors.New("result is empty")
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return nil, errors.New("Invalid hex quantity: " + s)
+	}
+	n, ok := new(big.Int).SetString(s[2:], 16)
+	if !ok {
+		return nil, errors.New("Invalid hex quantity: " + s)
+	}
+	return n, nil
+}
+
+// Parses hex encoded data such as the result of eth_call
+func parseProxyBytesResponse(r io.Reader) ([]byte, error) {
+	var s string
+	if err := parseProxyResponse(r, &s); err != nil {
+		return nil, err
+	}
+	// Empty data is "0x", a null or missing result is not an answer
+	if s == "" {
+		return nil, errors.New("result is empty")
+	}
+	b, err := decodeHex(s)
+	if err != nil || !strings.HasPrefix(s, "0x") {
+		return nil, errors.New("Invalid hex data: " + s)
+	}
+	return b, nil
+}
+
+// Storage slot holding the implementation address of EIP-1967 proxies,
+// keccak256("eip1967.proxy.implementation") - 1
+const eip1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+
+// Construct a new request to the proxy module
+func (c *Client) buildProxyRequest(action string, params url.Values) (*http.Request, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("module", "proxy")
+	params.Set("action", action)
+
+	return c.buildRequest(params)
+}
+
+// Construct a new POST request to the proxy module, for parameters too large
+// for a URL
+func (c *Client) buildProxyPostRequest(action string, params url.Values) (*http.Request, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("module", "proxy")
+	params.Set("action", action)
+
+	return c.buildPostRequest(params)
+}
+
+func (c *Client) buildBlockByNumberRequest(blockNumber int, fullTx bool) (*http.Request, error) {
+	if blockNumber < 0 {
+		return nil, errors.New("Block number must be >= 0")
+	}
+	params := url.Values{}
+	params.Set("tag", string(BlockNumberTag(blockNumber)))
+	params.Set("boolean", fmt.Sprint(fullTx))
+
+	return c.buildProxyRequest("eth_getBlockByNumber", params)
+}
+
+func (c *Client) buildProxyTransactionRequest(action, hash string) (*http.Request, error) {
+	if !strings.HasPrefix(hash, "0x") {
+		return nil, errors.New("Transaction hash must begin with 0x")
+	}
+	params := url.Values{}
+	params.Set("txhash", hash)
+
+	return c.buildProxyRequest(action, params)
+}
+
+func (c *Client) buildSendRawTransactionRequest(rawTx string) (*http.Request, error) {
+	if !strings.HasPrefix(rawTx, "0x") || len(rawTx) == 2 {
+		return nil, errors.New("Raw transaction must be 0x prefixed hex")
+	}
+	params := url.Values{}
+	params.Set("hex", rawTx)
+
+	return c.buildProxyPostRequest("eth_sendRawTransaction", params)
+}
+
+func (c *Client) buildCallRequest(to string, data []byte, tag BlockTag) (*http.Request, error) {
+	if err := validateAddress(to); err != nil {
+		return nil, err
+	}
+	tagParam, err := tag.param()
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("to", to)
+	params.Set("data", "0x"+hex.EncodeToString(data))
+	params.Set("tag", tagParam)
+
+	return c.buildProxyRequest("eth_call", params)
+}
+
+// Construct a new request for the state of an account at the given block
+func (c *Client) buildAccountStateRequest(action, addr string, params url.Values, tag BlockTag) (*http.Request, error) {
+	if err := validateAddress(addr); err != nil {
+		return nil, err
+	}
+	tagParam, err := tag.param()
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("address", addr)
+	params.Set("tag", tagParam)
+
+	return c.buildProxyRequest(action, params)
+}
+
+func (c *Client) buildStorageAtRequest(addr, position string, tag BlockTag) (*http.Request, error) {
+	if !strings.HasPrefix(position, "0x") {
+		return nil, errors.New("Storage position must begin with 0x")
+	}
+	params := url.Values{}
+	params.Set("position", position)
+
+	return c.buildAccountStateRequest("eth_getStorageAt", addr, params, tag)
+}
+
+func (c *Client) buildEstimateGasRequest(msg CallMsg) (*http.Request, error) {
+	params := url.Values{}
+	if msg.From != "" {
+		if err := validateAddress(msg.From); err != nil {
+			return nil, err
+		}
+		params.Set("from", msg.From)
+	}
+	if msg.To != "" {
+		if err := validateAddress(msg.To); err != nil {
+			return nil, err
+		}
+		params.Set("to", msg.To)
+	}
+	if msg.Value != nil {
+		if msg.Value.Sign() < 0 {
+			return nil, errors.New("Value must be >= 0")
+		}
+		params.Set("value", "0x"+msg.Value.Text(16))
+	}
+	if len(msg.Data) > 0 {
+		params.Set("data", "0x"+hex.EncodeToString(msg.Data))
+	}
+	if msg.Gas < 0 {
+		return nil, errors.New("Gas must be >= 0")
+	}
+	if msg.Gas > 0 {
+		params.Set("gas", fmt.Sprintf("0x%x", msg.Gas))
+	}
+
+	return c.buildProxyRequest("eth_estimateGas", params)
+}
+
+func (c *Client) blockNumber(ctx context.Context) (int, error) {
+	req, err := c.buildProxyRequest("eth_blockNumber", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return parseProxyIntResponse(resp.Body)
+}
+
+func (c *Client) blockByNumber(ctx context.Context, blockNumber int, fullTx bool) (*Block, error) {
+	req, err := c.buildBlockByNumberRequest(blockNumber, fullTx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyBlockResponse(resp.Body, fullTx)
+}
+
+// BlockNumber returns the number of the most recent block
+func (c *Client) BlockNumber() (int, error) {
+	return c.blockNumber(context.Background())
+}
+
+// BlockNumberContext returns the number of the most recent block with a
+// custom context
+func (c *Client) BlockNumberContext(ctx context.Context) (int, error) {
+	return c.blockNumber(ctx)
+}
+
+// BlockByNumber returns the block with the given number. If fullTx is true,
+// its transactions are included, otherwise only their hashes
+func (c *Client) BlockByNumber(blockNumber int, fullTx bool) (*Block, error) {
+	return c.blockByNumber(context.Background(), blockNumber, fullTx)
+}
+
+// BlockByNumberContext returns the block with the given number with a custom
+// context
+func (c *Client) BlockByNumberContext(ctx context.Context, blockNumber int, fullTx bool) (*Block, error) {
+	return c.blockByNumber(ctx, blockNumber, fullTx)
+}
+
+func (c *Client) transactionByHash(ctx context.Context, hash string) (*Transaction, error) {
+	req, err := c.buildProxyTransactionRequest("eth_getTransactionByHash", hash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyTransactionResponse(resp.Body)
+}
+
+func (c *Client) transactionReceipt(ctx context.Context, hash string) (*Receipt, error) {
+	req, err := c.buildProxyTransactionRequest("eth_getTransactionReceipt", hash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyReceiptResponse(resp.Body)
+}
+
+// TransactionByHash returns the transaction with the given hash. Its Block
+// is nil while it is pending
+func (c *Client) TransactionByHash(hash string) (*Transaction, error) {
+	return c.transactionByHash(context.Background(), hash)
+}
+
+// TransactionByHashContext returns the transaction with the given hash with
+// a custom context
+func (c *Client) TransactionByHashContext(ctx context.Context, hash string) (*Transaction, error) {
+	return c.transactionByHash(ctx, hash)
+}
+
+// TransactionReceipt returns the receipt of the mined transaction with the
+// given hash
+func (c *Client) TransactionReceipt(hash string) (*Receipt, error) {
+	return c.transactionReceipt(context.Background(), hash)
+}
+
+// TransactionReceiptContext returns the receipt of the mined transaction
+// with the given hash with a custom context
+func (c *Client) TransactionReceiptContext(ctx context.Context, hash string) (*Receipt, error) {
+	return c.transactionReceipt(ctx, hash)
+}
+
+func (c *Client) sendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	req, err := c.buildSendRawTransactionRequest(rawTx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return parseSendRawTransactionResponse(resp.Body)
+}
+
+// SendRawTransaction broadcasts a signed, hex encoded transaction and returns
+// its hash. Rejections by the node are returned as *RPCError, and can be
+// matched with ErrNonceTooLow, ErrTransactionUnderpriced and
+// ErrInsufficientFunds
+func (c *Client) SendRawTransaction(rawTx string) (string, error) {
+	return c.sendRawTransaction(context.Background(), rawTx)
+}
+
+// SendRawTransactionContext broadcasts a signed, hex encoded transaction
+// with a custom context
+func (c *Client) SendRawTransactionContext(ctx context.Context, rawTx string) (string, error) {
+	return c.sendRawTransaction(ctx, rawTx)
+}
+
+func (c *Client) call(ctx context.Context, to string, data []byte, tag BlockTag) ([]byte, error) {
+	req, err := c.buildCallRequest(to, data, tag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyBytesResponse(resp.Body)
+}
+
+func (c *Client) callMethod(ctx context.Context, abi *ABI, to, method string, args []interface{}) ([]interface{}, error) {
+	m, err := lookupFunction(abi, method)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeCall(m, args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.call(ctx, to, data, BlockTagLatest)
+	if err != nil {
+		return nil, err
+	}
+	// Calls to addresses without code succeed with no data
+	if len(result) == 0 && len(m.Outputs) > 0 {
+		return nil, errors.New("Call returned no data")
+	}
+	return decodeABIArguments(m.Outputs, result)
+}
+
+// Call executes a message call against the state at the given block without
+// creating a transaction, and returns its return data. If it reverts, the
+// error unwraps to a *RevertError
+func (c *Client) Call(to string, data []byte, tag BlockTag) ([]byte, error) {
+	return c.call(context.Background(), to, data, tag)
+}
+
+// CallContext executes a message call without creating a transaction with a
+// custom context
+func (c *Client) CallContext(ctx context.Context, to string, data []byte, tag BlockTag) ([]byte, error) {
+	return c.call(ctx, to, data, tag)
+}
+
+// CallMethod calls a function of the contract at the latest block, and
+// returns its decoded return values. The function and args are given as to
+// EncodeInput, by name or by signature to pick an overload
+func (c *Client) CallMethod(abi *ABI, to, method string, args ...interface{}) ([]interface{}, error) {
+	return c.callMethod(context.Background(), abi, to, method, args)
+}
+
+// CallMethodContext calls a function of the contract at the latest block
+// with a custom context
+func (c *Client) CallMethodContext(ctx context.Context, abi *ABI, to, method string, args ...interface{}) ([]interface{}, error) {
+	return c.callMethod(ctx, abi, to, method, args)
+}
+
+func (c *Client) transactionCount(ctx context.Context, addr string, tag BlockTag) (int, error) {
+	req, err := c.buildAccountStateRequest("eth_getTransactionCount", addr, nil, tag)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return parseProxyIntResponse(resp.Body)
+}
+
+func (c *Client) code(ctx context.Context, addr string, tag BlockTag) ([]byte, error) {
+	req, err := c.buildAccountStateRequest("eth_getCode", addr, nil, tag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyBytesResponse(resp.Body)
+}
+
+func (c *Client) storageAt(ctx context.Context, addr, position string, tag BlockTag) ([]byte, error) {
+	req, err := c.buildStorageAtRequest(addr, position, tag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyBytesResponse(resp.Body)
+}
+
+func (c *Client) isContract(ctx context.Context, addr string) (bool, error) {
+	code, err := c.code(ctx, addr, BlockTagLatest)
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+func (c *Client) implementationAddress(ctx context.Context, addr string) (string, error) {
+	slot, err := c.storageAt(ctx, addr, eip1967ImplementationSlot, BlockTagLatest)
+	if err != nil {
+		return "", err
+	}
+	return parseImplementationSlot(slot), nil
+}
+
+// Returns the address stored in the last 20 bytes of a storage slot, or an
+// empty string if the slot is empty
+func parseImplementationSlot(slot []byte) string {
+	if len(slot) < 20 {
+		return ""
+	}
+	addr := slot[len(slot)-20:]
+	for _, b := range addr {
+		if b != 0 {
+			return "0x" + hex.EncodeToString(addr)
+		}
+	}
+	return ""
+}
+
+// TransactionCount returns the number of transactions sent from the address
+// at the given block. With BlockTagPending, it is the next nonce to use
+func (c *Client) TransactionCount(addr string, tag BlockTag) (int, error) {
+	return c.transactionCount(context.Background(), addr, tag)
+}
+
+// TransactionCountContext returns the number of transactions sent from the
+// address at the given block with a custom context
+func (c *Client) TransactionCountContext(ctx context.Context, addr string, tag BlockTag) (int, error) {
+	return c.transactionCount(ctx, addr, tag)
+}
+
+// Code returns the runtime bytecode of the address at the given block, empty
+// for accounts without code
+func (c *Client) Code(addr string, tag BlockTag) ([]byte, error) {
+	return c.code(context.Background(), addr, tag)
+}
+
+// CodeContext returns the runtime bytecode of the address at the given block
+// with a custom context
+func (c *Client) CodeContext(ctx context.Context, addr string, tag BlockTag) ([]byte, error) {
+	return c.code(ctx, addr, tag)
+}
+
+// StorageAt returns the 32-byte storage slot of the address at the given
+// block. position is the hex encoded slot index
+func (c *Client) StorageAt(addr, position string, tag BlockTag) ([]byte, error) {
+	return c.storageAt(context.Background(), addr, position, tag)
+}
+
+// StorageAtContext returns the storage slot of the address at the given block
+// with a custom context
+func (c *Client) StorageAtContext(ctx context.Context, addr, position string, tag BlockTag) ([]byte, error) {
+	return c.storageAt(ctx, addr, position, tag)
+}
+
+// IsContract reports whether the address has code at the latest block
+func (c *Client) IsContract(addr string) (bool, error) {
+	return c.isContract(context.Background(), addr)
+}
+
+// IsContractContext reports whether the address has code at the latest block
+// with a custom context
+func (c *Client) IsContractContext(ctx context.Context, addr string) (bool, error) {
+	return c.isContract(ctx, addr)
+}
+
+// ImplementationAddress returns the implementation of an EIP-1967 proxy at
+// the address, read from its implementation slot. It is empty if the address
+// is not such a proxy
+func (c *Client) ImplementationAddress(addr string) (string, error) {
+	return c.implementationAddress(context.Background(), addr)
+}
+
+// ImplementationAddressContext returns the implementation of an EIP-1967
+// proxy at the address with a custom context
+func (c *Client) ImplementationAddressContext(ctx context.Context, addr string) (string, error) {
+	return c.implementationAddress(ctx, addr)
+}
+
+func (c *Client) gasPrice(ctx context.Context) (*big.Int, error) {
+	req, err := c.buildProxyRequest("eth_gasPrice", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseProxyBigResponse(resp.Body)
+}
+
+func (c *Client) estimateGas(ctx context.Context, msg CallMsg) (int, error) {
+	req, err := c.buildEstimateGasRequest(msg)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return parseProxyIntResponse(resp.Body)
+}
+
+// GasPrice returns the current gas price in wei
+func (c *Client) GasPrice() (*big.Int, error) {
+	return c.gasPrice(context.Background())
+}
+
+// GasPriceContext returns the current gas price in wei with a custom context
+func (c *Client) GasPriceContext(ctx context.Context) (*big.Int, error) {
+	return c.gasPrice(ctx)
+}
+
+// EstimateGas returns the gas needed to execute the message. If it reverts,
+// the error unwraps to a *RevertError
+func (c *Client) EstimateGas(msg CallMsg) (int, error) {
+	return c.estimateGas(context.Background(), msg)
+}
+
+// EstimateGasContext returns the gas needed to execute the message with a
+// custom context
+func (c *Client) EstimateGasContext(ctx context.Context, msg CallMsg) (int, error) {
+	return c.estimateGas(ctx, msg)
+}
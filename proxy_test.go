@@ -0,0 +1,387 @@
+package etherscan
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyBlockNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	n, err := parseProxyIntResponse(loadTestData(t, "proxy_block_number.json"))
+	assert.NoError(err)
+	assert.Equal(0xc36b29, n)
+
+	// Some nodes return string ids
+	n, err = parseProxyIntResponse(strings.NewReader(`{"jsonrpc":"2.0","id":"1","result":"0xc36b29"}`))
+	assert.NoError(err)
+	assert.Equal(0xc36b29, n)
+
+	_, err = parseProxyIntResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+	assert.EqualError(err, "API Error: Invalid API Key")
+
+	_, err = parseProxyIntResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`))
+	assert.EqualError(err, "Invalid hex quantity: Max rate limit reached")
+	_, err = parseProxyIntResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"0xzz"}`))
+	assert.Error(err)
+	_, err = parseProxyIntResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"0x"}`))
+	assert.Error(err)
+
+	_, err = parseProxyIntResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid argument 0: hex string without 0x prefix"}}`))
+	assert.IsType(&RPCError{}, err)
+	assert.Equal(-32602, err.(*RPCError).Code)
+}
+
+func TestProxyBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	block, err := parseProxyBlockResponse(loadTestData(t, "proxy_block.json"), true)
+	assert.NoError(err)
+	assert.Equal(0xc63251, block.Number)
+	assert.Equal("0x396288e0ad6690159d56b5502a172d54baea649698b4d7af2393cf5d98bf1bb3", block.Hash)
+	assert.Equal("0xbb2d43395f93dab5c424421be22d874f8c677e3f466dc993c218fa2cd90ef120", block.ParentHash)
+	assert.Equal("0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c", block.Miner)
+	assert.Equal(0x1caa87b, block.GasLimit)
+	assert.Equal(0x5f036a, block.GasUsed)
+	assert.EqualValues(big.NewInt(0x5cfe76044), block.BaseFee)
+	assert.EqualValues(time.Unix(0x610bd3e2, 0), block.Timestamp)
+	assert.Len(block.TransactionHashes, 2)
+	assert.Len(block.Transactions, 2)
+
+	tx := block.Transactions[0]
+	assert.Equal("0x2e8a2d53c0a4d3a8b9c4b4a7c4f1a0c8b2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7", tx.Hash)
+	assert.Equal(block.Number, tx.Block.Number)
+	assert.Equal(19, tx.Nonce)
+	assert.Equal(21000, tx.GasLimit)
+	assert.EqualValues(big.NewInt(30000000000), tx.GasPrice)
+	assert.EqualValues(big.NewInt(1000000000000000000), tx.Value)
+	assert.Equal(block.Timestamp, tx.Timestamp)
+
+	r := strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":{"number":"0x1","hash":"0x01","timestamp":"0x0","transactions":["0xaa","0xbb"]}}`)
+	block, err = parseProxyBlockResponse(r, false)
+	assert.NoError(err)
+	assert.Equal([]string{"0xaa", "0xbb"}, block.TransactionHashes)
+	assert.Nil(block.Transactions)
+	assert.Nil(block.BaseFee)
+
+	_, err = parseProxyBlockResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":null}`), false)
+	assert.Error(err)
+}
+
+func TestProxyTransaction(t *testing.T) {
+	assert := assert.New(t)
+
+	tx, err := parseProxyTransactionResponse(loadTestData(t, "proxy_transaction.json"))
+	assert.NoError(err)
+	assert.Equal("0xbc78ab8a9e9a0bca7d0321a27b2c03addeae08ba81ea98b03cd3dd237eabed44", tx.Hash)
+	assert.Equal(0xcf2420, tx.Block.Number)
+	assert.Equal(0x33b79d, tx.Nonce)
+	assert.Equal(0x5b, tx.Index)
+	assert.Equal(21000, tx.GasLimit)
+	assert.EqualValues(big.NewInt(0x19f017ef49), tx.GasPrice)
+	assert.EqualValues(big.NewInt(0x19755d4ce12c00), tx.Value)
+	assert.Equal(2, tx.Type)
+	assert.EqualValues(big.NewInt(0x1f6ea08600), tx.MaxFeePerGas)
+	assert.EqualValues(big.NewInt(1000000000), tx.MaxPriorityFeePerGas)
+
+	_, err = parseProxyTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	assert.Error(err)
+}
+
+func TestProxyReceipt(t *testing.T) {
+	assert := assert.New(t)
+
+	receipt, err := parseProxyReceiptResponse(loadTestData(t, "proxy_receipt.json"))
+	assert.NoError(err)
+	assert.Equal("0xadb8aec59e80db99811ac4a0235efa3e45da32928bcff557998552250fa672eb", receipt.TransactionHash)
+	assert.Equal(0xcf2427, receipt.BlockNumber)
+	assert.Equal(0x122, receipt.TransactionIndex)
+	assert.Empty(receipt.ContractAddress)
+	assert.Equal(0xb41d, receipt.GasUsed)
+	assert.Equal(0xeb67d5, receipt.CumulativeGasUsed)
+	assert.EqualValues(big.NewInt(0x1a96b24c26), receipt.EffectiveGasPrice)
+	assert.Equal(ReceiptStatusSuccess, receipt.Status)
+	assert.Equal(2, receipt.Type)
+
+	assert.Len(receipt.Logs, 1)
+	log := receipt.Logs[0]
+	assert.Equal("0xdac17f958d2ee523a2206206994597c13d831ec7", log.Address)
+	assert.Equal(0xdb, log.LogIndex)
+	assert.Equal(receipt.TransactionHash, log.TransactionHash)
+
+	transfer, err := DecodeTransfer(log)
+	assert.NoError(err)
+	assert.EqualValues(big.NewInt(0x13f81a6), transfer.Value)
+}
+
+func TestSendRawTransaction(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Client{
+		APIKey: "test123",
+	}
+	_, err := c.buildSendRawTransactionRequest("f86c")
+	assert.Error(err)
+
+	req, err := c.buildSendRawTransactionRequest("0xf86c")
+	assert.NoError(err)
+	assert.Equal("POST", req.Method)
+	assert.Empty(req.URL.RawQuery)
+	assert.NoError(req.ParseForm())
+	assert.Equal("proxy", req.PostForm.Get("module"))
+	assert.Equal("eth_sendRawTransaction", req.PostForm.Get("action"))
+	assert.Equal("0xf86c", req.PostForm.Get("hex"))
+
+	hash, err := parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"0xe670ec64341771606e55d6b4ca35a1a6b75ee3d5145a99d05921026d15273311"}`))
+	assert.NoError(err)
+	assert.Equal("0xe670ec64341771606e55d6b4ca35a1a6b75ee3d5145a99d05921026d15273311", hash)
+
+	// Rate limits are reported in the result, without a status
+	_, err = parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`))
+	assert.EqualError(err, "Invalid transaction hash: Max rate limit reached")
+	_, err = parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":"0xe670ec64"}`))
+	assert.Error(err)
+	_, err = parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	assert.Error(err)
+
+	var tests = []struct {
+		message string
+		err     error
+	}{
+		{"nonce too low", ErrNonceTooLow},
+		{"replacement transaction underpriced", ErrTransactionUnderpriced},
+		{"transaction underpriced", ErrTransactionUnderpriced},
+		{"insufficient funds for gas * price + value", ErrInsufficientFunds},
+	}
+	for _, test := range tests {
+		_, err := parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"` + test.message + `"}}`))
+		assert.True(errors.Is(err, test.err), test.message)
+		var rpcErr *RPCError
+		assert.True(errors.As(err, &rpcErr))
+		assert.Equal(-32000, rpcErr.Code)
+	}
+
+	_, err = parseSendRawTransactionResponse(strings.NewReader(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"already known"}}`))
+	assert.Error(err)
+	assert.False(errors.Is(err, ErrNonceTooLow))
+}
+
+func TestCallMethod(t *testing.T) {
+	assert := assert.New(t)
+	abi := loadTestABI(t)
+
+	fake := &fakeTransport{
+		body: `{"jsonrpc":"2.0","id":1,"result":"0x00000000000000000000000000000000000000000000021e19e0c9bab2400000"}`,
+	}
+	c := newFakeClient(fake)
+	values, err := c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "balanceOf", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a")
+	assert.NoError(err)
+	balance := &big.Int{}
+	balance.SetString("10000000000000000000000", 10)
+	assert.Len(values, 1)
+	assert.EqualValues(balance, values[0])
+
+	q := fake.req.URL.Query()
+	assert.Equal("eth_call", q.Get("action"))
+	assert.Equal("0xbb9bc244d798123fde783fcc1c72d3bb8c189413", q.Get("to"))
+	assert.Equal("0x70a08231000000000000000000000000ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", q.Get("data"))
+	assert.Equal("latest", q.Get("tag"))
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x"}`
+	_, err = c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "balanceOf", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a")
+	assert.Error(err)
+
+	_, err = c.Call("bb9bc244d798123fde783fcc1c72d3bb8c189413", nil, BlockTagLatest)
+	assert.Error(err)
+
+	_, err = c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "noSuchMethod")
+	assert.Error(err)
+	_, err = c.CallMethod(nil, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "balanceOf", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a")
+	assert.Error(err)
+}
+
+func TestCallMethodOverload(t *testing.T) {
+	assert := assert.New(t)
+	abi, err := ParseABI([]byte(`[
+		{"type":"function","name":"safeTransferFrom","inputs":[
+			{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}
+		],"outputs":[]},
+		{"type":"function","name":"safeTransferFrom","inputs":[
+			{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}
+		],"outputs":[{"name":"","type":"bool"}]}
+	]`))
+	assert.NoError(err)
+
+	fake := &fakeTransport{body: `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000001"}`}
+	c := newFakeClient(fake)
+	values, err := c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "safeTransferFrom(address, address, uint256, bytes)",
+		"0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", "0x198ef1ec325a96cc354c7266a038be8b5c558f67", 1, []byte{})
+	assert.NoError(err)
+	assert.Equal([]interface{}{true}, values)
+	assert.True(strings.HasPrefix(fake.req.URL.Query().Get("data"), "0xb88d4fde"))
+
+	// The name alone picks the first overload
+	_, err = c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "safeTransferFrom",
+		"0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a", "0x198ef1ec325a96cc354c7266a038be8b5c558f67", 1)
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(fake.req.URL.Query().Get("data"), "0x42842e0e"))
+
+	_, err = c.CallMethod(abi, "0xbb9bc244d798123fde783fcc1c72d3bb8c189413", "safeTransferFrom(address)", "0xddbd2b932c763ba5b1b7ae3b362eac3e8d40121a")
+	assert.Error(err)
+}
+
+func TestProxyAccountState(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := &fakeTransport{body: `{"jsonrpc":"2.0","id":1,"result":"0x4a"}`}
+	c := newFakeClient(fake)
+	nonce, err := c.TransactionCount("0x4bd5900cb274ef15b153066d736bf3e83a9ba44e", BlockTagPending)
+	assert.NoError(err)
+	assert.Equal(0x4a, nonce)
+	q := fake.req.URL.Query()
+	assert.Equal("eth_getTransactionCount", q.Get("action"))
+	assert.Equal("0x4bd5900cb274ef15b153066d736bf3e83a9ba44e", q.Get("address"))
+	assert.Equal("pending", q.Get("tag"))
+
+	// A nonce must never silently default to 0
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`
+	_, err = c.TransactionCount("0x4bd5900cb274ef15b153066d736bf3e83a9ba44e", BlockTagPending)
+	assert.Error(err)
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x"}`
+	isContract, err := c.IsContract("0x4bd5900cb274ef15b153066d736bf3e83a9ba44e")
+	assert.NoError(err)
+	assert.False(isContract)
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x6080604052"}`
+	code, err := c.Code("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c", BlockNumberTag(1000))
+	assert.NoError(err)
+	assert.Equal([]byte{0x60, 0x80, 0x60, 0x40, 0x52}, code)
+	assert.Equal("0x3e8", fake.req.URL.Query().Get("tag"))
+	isContract, err = c.IsContract("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c")
+	assert.NoError(err)
+	assert.True(isContract)
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x00000000000000000000000043506849d7c04f9138d1a2050bbf3a0c054402dd"}`
+	impl, err := c.ImplementationAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	assert.NoError(err)
+	assert.Equal("0x43506849d7c04f9138d1a2050bbf3a0c054402dd", impl)
+	q = fake.req.URL.Query()
+	assert.Equal("eth_getStorageAt", q.Get("action"))
+	assert.Equal(eip1967ImplementationSlot, q.Get("position"))
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000000"}`
+	impl, err = c.ImplementationAddress("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c")
+	assert.NoError(err)
+	assert.Empty(impl)
+
+	// Contract and proxy checks must never default to false
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"result":null}`,
+		`{"jsonrpc":"2.0","id":1}`,
+		`{"jsonrpc":"2.0","id":1,"result":""}`,
+		`{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`,
+	} {
+		fake.body = body
+		_, err = c.IsContract("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c")
+		assert.Error(err, body)
+		_, err = c.ImplementationAddress("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c")
+		assert.Error(err, body)
+	}
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`
+	_, err = c.Code("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c", BlockTagLatest)
+	assert.EqualError(err, "Invalid hex data: Max rate limit reached")
+
+	_, err = c.StorageAt("0xf75e354c5edc8efed9b59ee9f67a80845ade7d0c", "0", BlockTagLatest)
+	assert.Error(err)
+	_, err = c.TransactionCount("f75e354c5edc8efed9b59ee9f67a80845ade7d0c", BlockTagLatest)
+	assert.Error(err)
+}
+
+func TestGasPrice(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := &fakeTransport{body: `{"jsonrpc":"2.0","id":1,"result":"0x430e23400"}`}
+	c := newFakeClient(fake)
+	price, err := c.GasPrice()
+	assert.NoError(err)
+	assert.EqualValues(big.NewInt(18000000000), price)
+	assert.Equal("eth_gasPrice", fake.req.URL.Query().Get("action"))
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"Max rate limit reached"}`
+	_, err = c.GasPrice()
+	assert.EqualError(err, "Invalid hex quantity: Max rate limit reached")
+	fake.body = `{"jsonrpc":"2.0","id":1,"result":"0x"}`
+	_, err = c.GasPrice()
+	assert.Error(err)
+}
+
+func TestEstimateGas(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := &fakeTransport{body: `{"jsonrpc":"2.0","id":1,"result":"0x5208"}`}
+	c := newFakeClient(fake)
+	gas, err := c.EstimateGas(CallMsg{
+		To:    "0xf0160428a8552ac9bb7e050d90eeade4ddd52843",
+		Value: big.NewInt(0xff22),
+		Data:  []byte{0x41, 0x42},
+		Gas:   0x5f5e0ff,
+	})
+	assert.NoError(err)
+	assert.Equal(21000, gas)
+	q := fake.req.URL.Query()
+	assert.Equal("eth_estimateGas", q.Get("action"))
+	assert.Equal("0xf0160428a8552ac9bb7e050d90eeade4ddd52843", q.Get("to"))
+	assert.Equal("0xff22", q.Get("value"))
+	assert.Equal("0x4142", q.Get("data"))
+	assert.Equal("0x5f5e0ff", q.Get("gas"))
+	assert.Empty(q.Get("from"))
+
+	_, err = c.EstimateGas(CallMsg{To: "f0160428a8552ac9bb7e050d90eeade4ddd52843"})
+	assert.Error(err)
+	_, err = c.EstimateGas(CallMsg{Value: big.NewInt(-1)})
+	assert.Error(err)
+}
+
+func TestRevertError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Error("Ownable: caller is not the owner")
+	fake := &fakeTransport{body: `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted: Ownable: caller is not the owner","data":"0x08c379a0` +
+		`0000000000000000000000000000000000000000000000000000000000000020` +
+		`0000000000000000000000000000000000000000000000000000000000000020` +
+		`4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572"}}`}
+	c := newFakeClient(fake)
+	_, err := c.EstimateGas(CallMsg{To: "0xf0160428a8552ac9bb7e050d90eeade4ddd52843"})
+	var revertErr *RevertError
+	assert.True(errors.As(err, &revertErr))
+	assert.Equal("Ownable: caller is not the owner", revertErr.Reason)
+	assert.Len(revertErr.Data, 100)
+	var rpcErr *RPCError
+	assert.True(errors.As(err, &rpcErr))
+	assert.Equal(3, rpcErr.Code)
+
+	// Custom errors are only available as data
+	fake.body = `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted","data":"0x82b42900"}}`
+	_, err = c.Call("0xf0160428a8552ac9bb7e050d90eeade4ddd52843", nil, BlockTagLatest)
+	assert.True(errors.As(err, &revertErr))
+	assert.Empty(revertErr.Reason)
+	assert.Equal([]byte{0x82, 0xb4, 0x29, 0x00}, revertErr.Data)
+	assert.Equal("execution reverted", revertErr.Error())
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted: paused"}}`
+	_, err = c.EstimateGas(CallMsg{})
+	assert.True(errors.As(err, &revertErr))
+	assert.Equal("paused", revertErr.Reason)
+	assert.Nil(revertErr.Data)
+
+	fake.body = `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"gas required exceeds allowance"}}`
+	_, err = c.EstimateGas(CallMsg{})
+	assert.Error(err)
+	assert.False(errors.As(err, &revertErr))
+}
@@ -1,6 +1,7 @@
 package etherscan
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"strconv"
@@ -24,6 +25,20 @@ func checkResponse(resp *baseResponse) error {
 	return nil
 }
 
+// Checks for error in a response whose result is only decoded on success.
+// Failed requests usually report the reason as a string result, such as an
+// invalid API key or rate limit
+func checkRawResponse(resp *baseResponse, result json.RawMessage) error {
+	if err := checkResponse(resp); err != nil {
+		var msg string
+		if json.Unmarshal(result, &msg) == nil && msg != "" {
+			return errors.New("API Error: " + msg)
+		}
+		return err
+	}
+	return nil
+}
+
 // Parse integer and silently discard error
 func parseInt(s string) int {
 	n, _ := strconv.Atoi(s)
@@ -0,0 +1,180 @@
+package etherscan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SignatureRegistry maps function selectors and event topics to their
+// signatures, to label calls and logs of contracts without a verified ABI.
+// The zero value is an empty registry. It is safe for concurrent use
+type SignatureRegistry struct {
+	mu sync.RWMutex
+
+	// Signatures keyed by selector or topic, in the order they were added
+	methods map[string][]string
+	events  map[string][]string
+}
+
+// NewSignatureRegistry returns a registry seeded with common ERC20, ERC721,
+// ERC1155, Uniswap and multisig wallet signatures
+func NewSignatureRegistry() *SignatureRegistry {
+	r := &SignatureRegistry{}
+	if err := r.Load(strings.NewReader(defaultSignatures)); err != nil {
+		panic("etherscan: invalid default signatures: " + err.Error())
+	}
+	return r
+}
+
+// Adds a signature to a map unless already present
+func addSignature(m map[string][]string, key, sig string) {
+	for _, s := range m[key] {
+		if s == sig {
+			return
+		}
+	}
+	m[key] = append(m[key], sig)
+}
+
+// AddMethod registers a function signature such as
+// "transfer(address,uint256)"
+func (r *SignatureRegistry) AddMethod(sig string) error {
+	name, args, err := parseSignature(strings.TrimPrefix(strings.TrimSpace(sig), "function "))
+	if err != nil {
+		return err
+	}
+	method := &ABIMethod{Name: name, Inputs: args}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.methods == nil {
+		r.methods = make(map[string][]string)
+	}
+	addSignature(r.methods, method.Selector(), method.Signature())
+	return nil
+}
+
+// AddEvent registers an event signature such as
+// "Transfer(address indexed,address indexed,uint256)"
+func (r *SignatureRegistry) AddEvent(sig string) error {
+	event, err := ParseEventSignature(sig)
+	if err != nil {
+		return err
+	}
+	r.addEvent(event)
+	return nil
+}
+
+func (r *SignatureRegistry) addEvent(event *ABIEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.events == nil {
+		r.events = make(map[string][]string)
+	}
+	addSignature(r.events, event.Topic(), event.Signature())
+}
+
+// AddABI registers all functions and events of a parsed ABI
+func (r *SignatureRegistry) AddABI(abi *ABI) {
+	r.mu.Lock()
+	if r.methods == nil {
+		r.methods = make(map[string][]string)
+	}
+	for _, m := range abi.Functions {
+		addSignature(r.methods, m.Selector(), m.Signature())
+	}
+	r.mu.Unlock()
+
+	for _, e := range abi.Events {
+		if !e.Anonymous {
+			r.addEvent(e)
+		}
+	}
+}
+
+// Load registers signatures read from r, one per line, prefixed with
+// "function" or "event". Empty lines and lines starting with # are ignored
+func (r *SignatureRegistry) Load(rd io.Reader) error {
+	scanner := bufio.NewScanner(rd)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var err error
+		switch {
+		case strings.HasPrefix(line, "function "):
+			err = r.AddMethod(line)
+		case strings.HasPrefix(line, "event "):
+			err = r.AddEvent(line)
+		default:
+			err = fmt.Errorf("Signature must begin with function or event: %s", line)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %s", n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// LoadFile registers signatures read from the file at path, in the format
+// described by Load
+func (r *SignatureRegistry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.Load(f)
+}
+
+// LookupMethod returns the signatures registered for a 4-byte function
+// selector such as "0xa9059cbb"
+func (r *SignatureRegistry) LookupMethod(selector string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.methods[strings.ToLower(selector)]...)
+}
+
+// LookupEvent returns the signatures registered for an event topic0 hash
+func (r *SignatureRegistry) LookupEvent(topic string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.events[strings.ToLower(topic)]...)
+}
+
+// Returns the name of a signature such as "transfer(address,uint256)"
+func signatureName(sig string) string {
+	return sig[:strings.Index(sig, "(")]
+}
+
+// MethodName returns a best guess of the name of the function called by the
+// transaction, from the first signature registered for its selector
+func (r *SignatureRegistry) MethodName(tx *Transaction) (string, bool) {
+	data := strings.TrimPrefix(tx.Data, "0x")
+	if len(data) < 8 {
+		return "", false
+	}
+	sigs := r.LookupMethod("0x" + data[:8])
+	if len(sigs) == 0 {
+		return "", false
+	}
+	return signatureName(sigs[0]), true
+}
+
+// EventName returns a best guess of the name of the event of the log, from
+// the first signature registered for its topic0 hash
+func (r *SignatureRegistry) EventName(log EventLog) (string, bool) {
+	if len(log.Topics) == 0 {
+		return "", false
+	}
+	sigs := r.LookupEvent(log.Topics[0])
+	if len(sigs) == 0 {
+		return "", false
+	}
+	return signatureName(sigs[0]), true
+}
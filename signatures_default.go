@@ -0,0 +1,121 @@
+package etherscan
+
+// Common function and event signatures loaded by NewSignatureRegistry. One
+// signature per line, prefixed with "function" or "event"
+const defaultSignatures = `# ERC20
+function totalSupply()
+function balanceOf(address)
+function transfer(address,uint256)
+function transferFrom(address,address,uint256)
+function approve(address,uint256)
+function allowance(address,address)
+function name()
+function symbol()
+function decimals()
+function increaseAllowance(address,uint256)
+function decreaseAllowance(address,uint256)
+function permit(address,address,uint256,uint256,uint8,bytes32,bytes32)
+function mint(address,uint256)
+function burn(uint256)
+function burnFrom(address,uint256)
+event Transfer(address indexed,address indexed,uint256)
+event Approval(address indexed,address indexed,uint256)
+
+# WETH
+function deposit()
+function withdraw(uint256)
+event Deposit(address indexed,uint256)
+event Withdrawal(address indexed,uint256)
+
+# ERC721
+function ownerOf(uint256)
+function safeTransferFrom(address,address,uint256)
+function safeTransferFrom(address,address,uint256,bytes)
+function setApprovalForAll(address,bool)
+function getApproved(uint256)
+function isApprovedForAll(address,address)
+function tokenURI(uint256)
+function safeMint(address,uint256)
+event ApprovalForAll(address indexed,address indexed,bool)
+
+# ERC1155
+function safeTransferFrom(address,address,uint256,uint256,bytes)
+function safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)
+function balanceOfBatch(address[],uint256[])
+function uri(uint256)
+event TransferSingle(address indexed,address indexed,address indexed,uint256,uint256)
+event TransferBatch(address indexed,address indexed,address indexed,uint256[],uint256[])
+event URI(string,uint256 indexed)
+
+# Uniswap V2 router
+function swapExactTokensForTokens(uint256,uint256,address[],address,uint256)
+function swapTokensForExactTokens(uint256,uint256,address[],address,uint256)
+function swapExactETHForTokens(uint256,address[],address,uint256)
+function swapTokensForExactETH(uint256,uint256,address[],address,uint256)
+function swapExactTokensForETH(uint256,uint256,address[],address,uint256)
+function swapETHForExactTokens(uint256,address[],address,uint256)
+function swapExactTokensForTokensSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)
+function swapExactETHForTokensSupportingFeeOnTransferTokens(uint256,address[],address,uint256)
+function swapExactTokensForETHSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)
+function addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)
+function addLiquidityETH(address,uint256,uint256,uint256,address,uint256)
+function removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)
+function removeLiquidityETH(address,uint256,uint256,uint256,address,uint256)
+
+# Uniswap V2 pair and factory
+function swap(uint256,uint256,address,bytes)
+function sync()
+function skim(address)
+function mint(address)
+function burn(address)
+function createPair(address,address)
+event Swap(address indexed,uint256,uint256,uint256,uint256,address indexed)
+event Sync(uint112,uint112)
+event Mint(address indexed,uint256,uint256)
+event Burn(address indexed,uint256,uint256,address indexed)
+event PairCreated(address indexed,address indexed,address,uint256)
+
+# Uniswap V3
+function exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))
+function exactInput((bytes,address,uint256,uint256,uint256))
+function exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))
+function exactOutput((bytes,address,uint256,uint256,uint256))
+function multicall(bytes[])
+function multicall(uint256,bytes[])
+function execute(bytes,bytes[],uint256)
+event Swap(address indexed,address indexed,int256,int256,uint160,uint128,int24)
+
+# Gnosis Safe
+function execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)
+function addOwnerWithThreshold(address,uint256)
+function removeOwner(address,address,uint256)
+function swapOwner(address,address,address)
+function changeThreshold(uint256)
+function approveHash(bytes32)
+event ExecutionSuccess(bytes32,uint256)
+event ExecutionFailure(bytes32,uint256)
+event AddedOwner(address)
+event RemovedOwner(address)
+event ChangedThreshold(uint256)
+
+# Gnosis MultiSigWallet
+function submitTransaction(address,uint256,bytes)
+function confirmTransaction(uint256)
+function revokeConfirmation(uint256)
+function executeTransaction(uint256)
+event Submission(uint256 indexed)
+event Confirmation(address indexed,uint256 indexed)
+event Revocation(address indexed,uint256 indexed)
+event Execution(uint256 indexed)
+event ExecutionFailure(uint256 indexed)
+
+# Ownable and upgradeable proxies
+function owner()
+function transferOwnership(address)
+function renounceOwnership()
+function upgradeTo(address)
+function upgradeToAndCall(address,bytes)
+event OwnershipTransferred(address indexed,address indexed)
+event Upgraded(address indexed)
+event AdminChanged(address,address)
+`
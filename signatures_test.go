@@ -0,0 +1,59 @@
+package etherscan
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureRegistry(t *testing.T) {
+	assert := assert.New(t)
+	r := NewSignatureRegistry()
+
+	assert.Equal([]string{"transfer(address,uint256)"}, r.LookupMethod("0xa9059cbb"))
+	assert.Equal([]string{"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)"}, r.LookupMethod("0x38ed1739"))
+	assert.Equal([]string{"execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)"}, r.LookupMethod("0x6a761202"))
+	assert.Equal([]string{"Transfer(address,address,uint256)"}, r.LookupEvent("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	assert.Equal([]string{"TransferSingle(address,address,address,uint256,uint256)"}, r.LookupEvent("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"))
+
+	name, ok := r.MethodName(&Transaction{Data: "0xa9059cbb000000000000000000000000ddbd2b932c763ba5b1b7ae3b362eac3e8d40121a"})
+	assert.True(ok)
+	assert.Equal("transfer", name)
+	_, ok = r.MethodName(&Transaction{Data: "0x"})
+	assert.False(ok)
+
+	name, ok = r.EventName(testTransferLog)
+	assert.True(ok)
+	assert.Equal("Transfer", name)
+}
+
+func TestSignatureRegistryLoad(t *testing.T) {
+	assert := assert.New(t)
+	r := &SignatureRegistry{}
+
+	_, ok := r.EventName(testTransferLog)
+	assert.False(ok)
+
+	dir, err := ioutil.TempDir("", "etherscan")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "signatures.txt")
+	err = ioutil.WriteFile(path, []byte("# custom\nfunction claim(uint256,bytes32[])\nevent Claimed(address indexed,uint256)\n"), 0644)
+	assert.NoError(err)
+	assert.NoError(r.LoadFile(path))
+
+	claim := &ABIMethod{Name: "claim", Inputs: []ABIArgument{{Type: "uint256"}, {Type: "bytes32[]"}}}
+	assert.Equal([]string{"claim(uint256,bytes32[])"}, r.LookupMethod(claim.Selector()))
+
+	r.AddABI(loadTestABI(t))
+	name, ok := r.EventName(testTransferLog)
+	assert.True(ok)
+	assert.Equal("Transfer", name)
+
+	assert.Error(r.Load(strings.NewReader("transfer(address,uint256)")))
+	assert.Error(r.AddMethod("transfer(address"))
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -19,6 +20,60 @@ type Token struct {
 	Decimals int
 }
 
+// NFTTransfer is a transfer of a single ERC721 token. The embedded
+// Transaction carries the token contract and metadata
+type NFTTransfer struct {
+	*Transaction
+
+	// ID of the transferred token within its contract
+	TokenID *big.Int
+}
+
+func parseNFTTransfer(tx *transactionResponse) *NFTTransfer {
+	return &NFTTransfer{
+		Transaction: parseTransaction(tx),
+		TokenID:     parseBig(tx.TokenID),
+	}
+}
+
+// ERC1155Transfer is a transfer of an amount of a single ERC1155 token. The
+// embedded Transaction carries the token contract and metadata
+type ERC1155Transfer struct {
+	*Transaction
+
+	// ID of the transferred token within its contract
+	TokenID *big.Int
+
+	// Amount of the token transferred
+	TokenValue *big.Int
+}
+
+func parseERC1155Transfer(tx *transactionResponse) *ERC1155Transfer {
+	return &ERC1155Transfer{
+		Transaction: parseTransaction(tx),
+		TokenID:     parseBig(tx.TokenID),
+		TokenValue:  parseBig(tx.TokenValue),
+	}
+}
+
+// GroupERC1155Transfers groups transfers by transaction hash, so that the
+// tokens moved by a single batch transfer end up together. Groups are
+// returned in the order their first transfer appears
+func GroupERC1155Transfers(transfers []*ERC1155Transfer) [][]*ERC1155Transfer {
+	var groups [][]*ERC1155Transfer
+	index := make(map[string]int)
+	for _, t := range transfers {
+		i, ok := index[t.Hash]
+		if !ok {
+			i = len(groups)
+			index[t.Hash] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], t)
+	}
+	return groups
+}
+
 type tokenResponse struct {
 	*baseResponse
 	Total string `json:"result"`
@@ -57,17 +112,39 @@ func (c *Client) buildTokenTotalSupplyRequest(contractAddress string) (*http.Req
 	return c.buildRequest(params)
 }
 
-func (c *Client) buildTokenTotalBalanceRequest(contractAddress, address string) (*http.Request, error) {
+func (c *Client) buildTokenTotalBalanceRequest(contractAddress, address string, tag BlockTag) (*http.Request, error) {
 	if !strings.HasPrefix(contractAddress, "0x") {
 		return nil, errors.New("Contract address must begin with 0x")
 	}
+	tagParam, err := tag.param()
+	if err != nil {
+		return nil, err
+	}
 
 	params := url.Values{}
 	params.Set("module", "account")
 	params.Set("action", "tokenbalance")
-	params.Set("tag", "latest")
+	params.Set("tag", tagParam)
+	params.Set("contractaddress", contractAddress)
+	params.Set("address", address)
+
+	return c.buildRequest(params)
+}
+
+func (c *Client) buildTokenBalanceHistoryRequest(contractAddress, address string, blockNumber int) (*http.Request, error) {
+	if !strings.HasPrefix(contractAddress, "0x") {
+		return nil, errors.New("Contract address must begin with 0x")
+	}
+	if blockNumber < 0 {
+		return nil, errors.New("Block number must be >= 0")
+	}
+
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "tokenbalancehistory")
 	params.Set("contractaddress", contractAddress)
 	params.Set("address", address)
+	params.Set("blockno", strconv.Itoa(blockNumber))
 
 	return c.buildRequest(params)
 }
@@ -85,12 +162,28 @@ func (c *Client) tokenTotalSupply(ctx context.Context, contractAddress string) (
 	return parseTokenResponse(resp.Body)
 }
 
-func (c *Client) tokenTotalBalance(ctx context.Context, contractAddress, address string) (*big.Int, error) {
-	req, err := c.buildTokenTotalBalanceRequest(contractAddress, address)
+func (c *Client) tokenTotalBalance(ctx context.Context, contractAddress, address string, tag BlockTag) (*big.Int, error) {
+	req, err := c.buildTokenTotalBalanceRequest(contractAddress, address, tag)
 	if err != nil {
 		return nil, err
 	}
 	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseTokenResponse(resp.Body)
+}
+
+func (c *Client) tokenBalanceHistory(ctx context.Context, contractAddress, address string, blockNumber int) (*big.Int, error) {
+	req, err := c.buildTokenBalanceHistoryRequest(contractAddress, address, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 	return parseTokenResponse(resp.Body)
 }
@@ -107,10 +200,133 @@ func (c *Client) TokenTotalSupplyContext(ctx context.Context, contractAddress st
 
 // TokenTotalBalance returns ERC20-Token Account Balance for TokenContractAddress
 func (c Client) TokenTotalBalance(contractAddress string, address string) (*big.Int, error) {
-	return c.tokenTotalBalance(context.Background(), contractAddress, address)
+	return c.tokenTotalBalance(context.Background(), contractAddress, address, BlockTagLatest)
 }
 
 // TokenTotalBalanceContext returns ERC20-Token Account Balance for TokenContractAddress with a custom context
 func (c Client) TokenTotalBalanceContext(ctx context.Context, contractAddress string, address string) (*big.Int, error) {
-	return c.tokenTotalBalance(ctx, contractAddress, address)
+	return c.tokenTotalBalance(ctx, contractAddress, address, BlockTagLatest)
+}
+
+// TokenTotalBalanceAt returns ERC20-Token Account Balance for TokenContractAddress
+// at the given block tag
+func (c *Client) TokenTotalBalanceAt(contractAddress string, address string, tag BlockTag) (*big.Int, error) {
+	return c.tokenTotalBalance(context.Background(), contractAddress, address, tag)
+}
+
+// TokenTotalBalanceAtContext returns ERC20-Token Account Balance for TokenContractAddress
+// at the given block tag with a custom context
+func (c *Client) TokenTotalBalanceAtContext(ctx context.Context, contractAddress string, address string, tag BlockTag) (*big.Int, error) {
+	return c.tokenTotalBalance(ctx, contractAddress, address, tag)
+}
+
+// TokenBalanceHistory returns the historical ERC20-Token Account Balance for
+// TokenContractAddress at the given block number
+func (c *Client) TokenBalanceHistory(contractAddress string, address string, blockNumber int) (*big.Int, error) {
+	return c.tokenBalanceHistory(context.Background(), contractAddress, address, blockNumber)
+}
+
+// TokenBalanceHistoryContext returns the historical ERC20-Token Account Balance for
+// TokenContractAddress at the given block number with a custom context
+func (c *Client) TokenBalanceHistoryContext(ctx context.Context, contractAddress string, address string, blockNumber int) (*big.Int, error) {
+	return c.tokenBalanceHistory(ctx, contractAddress, address, blockNumber)
+}
+
+func (c *Client) nftTransactions(ctx context.Context, addr string, options TransactionListOptions) ([]*NFTTransfer, error) {
+	res, err := c.transactionResponses(ctx, addr, options, txNFT)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*NFTTransfer, len(res))
+	for i, tx := range res {
+		transfers[i] = parseNFTTransfer(tx)
+	}
+	return transfers, nil
+}
+
+// Fetches a single page of ERC721 token transfers, newest first
+func (c *Client) nftTransactionsPage(ctx context.Context, addr string, page, offset int) ([]*NFTTransfer, error) {
+	if page <= 0 {
+		return nil, errors.New("page param must >= 1")
+	}
+	options := TransactionListOptions{
+		Sort:   SortDesc,
+		Page:   page,
+		Offset: offset,
+	}
+	return c.nftTransactions(ctx, addr, options)
+}
+
+// NFTTransactions returns a list of ERC721 token transfers to/from the given address
+func (c *Client) NFTTransactions(addr string, page, offset int) ([]*NFTTransfer, error) {
+	return c.nftTransactionsPage(context.Background(), addr, page, offset)
+}
+
+// NFTTransactionsContext returns a list of ERC721 token transfers to/from the given address
+// with a custom context
+func (c *Client) NFTTransactionsContext(ctx context.Context, addr string, page, offset int) ([]*NFTTransfer, error) {
+	return c.nftTransactionsPage(ctx, addr, page, offset)
+}
+
+// NFTTransactionsWithOptions returns a list of ERC721 token transfers to/from
+// the given address, filtered by options. The address may be empty if
+// options.ContractAddress is set
+func (c *Client) NFTTransactionsWithOptions(addr string, options TransactionListOptions) ([]*NFTTransfer, error) {
+	return c.nftTransactions(context.Background(), addr, options)
+}
+
+// NFTTransactionsWithOptionsContext returns a list of ERC721 token transfers
+// to/from the given address, filtered by options, with a custom context
+func (c *Client) NFTTransactionsWithOptionsContext(ctx context.Context, addr string, options TransactionListOptions) ([]*NFTTransfer, error) {
+	return c.nftTransactions(ctx, addr, options)
+}
+
+func (c *Client) erc1155Transactions(ctx context.Context, addr string, options TransactionListOptions) ([]*ERC1155Transfer, error) {
+	res, err := c.transactionResponses(ctx, addr, options, txERC1155)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*ERC1155Transfer, len(res))
+	for i, tx := range res {
+		transfers[i] = parseERC1155Transfer(tx)
+	}
+	return transfers, nil
+}
+
+// Fetches a single page of ERC1155 token transfers, newest first
+func (c *Client) erc1155TransactionsPage(ctx context.Context, addr string, page, offset int) ([]*ERC1155Transfer, error) {
+	if page <= 0 {
+		return nil, errors.New("page param must >= 1")
+	}
+	options := TransactionListOptions{
+		Sort:   SortDesc,
+		Page:   page,
+		Offset: offset,
+	}
+	return c.erc1155Transactions(ctx, addr, options)
+}
+
+// ERC1155Transactions returns a list of ERC1155 token transfers to/from the given address
+func (c *Client) ERC1155Transactions(addr string, page, offset int) ([]*ERC1155Transfer, error) {
+	return c.erc1155TransactionsPage(context.Background(), addr, page, offset)
+}
+
+// ERC1155TransactionsContext returns a list of ERC1155 token transfers to/from the given address
+// with a custom context
+func (c *Client) ERC1155TransactionsContext(ctx context.Context, addr string, page, offset int) ([]*ERC1155Transfer, error) {
+	return c.erc1155TransactionsPage(ctx, addr, page, offset)
+}
+
+// ERC1155TransactionsWithOptions returns a list of ERC1155 token transfers
+// to/from the given address, filtered by options. The address may be empty
+// if options.ContractAddress is set
+func (c *Client) ERC1155TransactionsWithOptions(addr string, options TransactionListOptions) ([]*ERC1155Transfer, error) {
+	return c.erc1155Transactions(context.Background(), addr, options)
+}
+
+// ERC1155TransactionsWithOptionsContext returns a list of ERC1155 token
+// transfers to/from the given address, filtered by options, with a custom
+// context
+func (c *Client) ERC1155TransactionsWithOptionsContext(ctx context.Context, addr string, options TransactionListOptions) ([]*ERC1155Transfer, error) {
+	return c.erc1155Transactions(ctx, addr, options)
 }
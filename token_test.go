@@ -30,3 +30,45 @@ func TestTokenBalance(t *testing.T) {
 	val.SetString("135499", 10)
 	assert.EqualValues(val, totalBalance)
 }
+
+func TestNFTTransactions(t *testing.T) {
+	assert := assert.New(t)
+
+	r := loadTestData(t, "token_nft_transactions.json")
+	res, err := decodeTransactionsResponse(r)
+	assert.NoError(err)
+	assert.Len(res, 1)
+
+	transfer := parseNFTTransfer(res[0])
+	assert.EqualValues(big.NewInt(202106), transfer.TokenID)
+	assert.Equal("0x06012c8cf97bead5deae237070f9587f8e7a266d", transfer.ContractAddress)
+	assert.Equal("0x031e6968a8de362e4328d60dcc7f72f0d6fc84284c452f63176632177146de66", transfer.Hash)
+	assert.Equal(4708120, transfer.Block.Number)
+	assert.Equal("CryptoKitties", transfer.Token.Name)
+	assert.Equal("CK", transfer.Token.Symbol)
+	assert.Equal(0, transfer.Token.Decimals)
+}
+
+func TestERC1155Transactions(t *testing.T) {
+	assert := assert.New(t)
+
+	r := loadTestData(t, "token_1155_transactions.json")
+	res, err := decodeTransactionsResponse(r)
+	assert.NoError(err)
+	assert.Len(res, 3)
+
+	transfers := make([]*ERC1155Transfer, len(res))
+	for i, tx := range res {
+		transfers[i] = parseERC1155Transfer(tx)
+	}
+	transfer := transfers[1]
+	assert.EqualValues(big.NewInt(10372), transfer.TokenID)
+	assert.EqualValues(big.NewInt(3), transfer.TokenValue)
+	assert.Equal("0x76be3b62873462d2142405439777e971754e8e77", transfer.ContractAddress)
+	assert.Equal("LL", transfer.Token.Symbol)
+
+	groups := GroupERC1155Transfers(transfers)
+	assert.Len(groups, 2)
+	assert.Len(groups[0], 2)
+	assert.Equal(transfers[2], groups[1][0])
+}
@@ -9,6 +9,8 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,12 +21,76 @@ const (
 	txNormal = iota
 	txInternal
 	txToken
+	txNFT
+	txERC1155
 )
 
+// SortOrder is the order in which transaction lists are returned
+type SortOrder string
+
+const (
+	// Oldest transactions first
+	SortAsc SortOrder = "asc"
+	// Newest transactions first
+	SortDesc SortOrder = "desc"
+)
+
+// TransactionListOptions filters and paginates transaction list queries
+type TransactionListOptions struct {
+	// First block to include. Default: 0
+	StartBlock int
+
+	// Last block to include. Default: latest
+	EndBlock int
+
+	// Default: SortDesc
+	Sort SortOrder
+
+	// Page number, starting at 1. Pagination is disabled if Page is 0
+	Page int
+
+	// Number of transactions per page
+	Offset int
+
+	// Only return transfers of this token contract. Token, NFT and ERC1155
+	// transfers only
+	ContractAddress string
+}
+
+// Checks that the options are consistent for the category of transactions
+func (o *TransactionListOptions) validate(category txType) error {
+	if o.StartBlock < 0 || o.EndBlock < 0 {
+		return errors.New("Block numbers must be >= 0")
+	}
+	if o.EndBlock != 0 && o.EndBlock < o.StartBlock {
+		return errors.New("EndBlock must be >= StartBlock")
+	}
+	switch o.Sort {
+	case "", SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("Invalid sort order: %s", o.Sort)
+	}
+	if o.Page < 0 || o.Offset < 0 {
+		return errors.New("Page and Offset must be >= 0")
+	}
+	if o.Page == 0 && o.Offset != 0 {
+		return errors.New("Offset requires Page to be set")
+	}
+	if o.ContractAddress != "" {
+		if category != txToken && category != txNFT && category != txERC1155 {
+			return errors.New("ContractAddress is only supported for token transactions")
+		}
+		if !strings.HasPrefix(o.ContractAddress, "0x") {
+			return errors.New("Contract address must begin with 0x")
+		}
+	}
+	return nil
+}
+
 // Response with list of transactions for an address
 type transactionsResponse struct {
 	*baseResponse
-	Transactions []*transactionResponse `json:"result"`
+	Result json.RawMessage `json:"result"`
 }
 
 // An unparsed transaction
@@ -41,6 +107,8 @@ type transactionResponse struct {
 	TokenName         string `json:"tokenName"`
 	TokenSymbol       string `json:"tokenSymbol"`
 	TokenDecimal      string `json:"tokenDecimal"`
+	TokenID           string `json:"tokenID"`
+	TokenValue        string `json:"tokenValue"`
 	Gas               string `json:"gas"`
 	GasPrice          string `json:"gasPrice"`
 	IsError           string `json:"isError"`
@@ -90,6 +158,14 @@ type Transaction struct {
 	// Gas price in wei
 	GasPrice *big.Int
 
+	// EIP-2718 transaction type, 2 for EIP-1559 fee market transactions.
+	// Only set by TransactionByHash and BlockByNumber
+	Type int
+
+	// EIP-1559 fee caps in wei, nil for legacy transactions
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
 	IsError bool
 
 	// Detailed error from contract
@@ -101,11 +177,126 @@ type Transaction struct {
 	Data string
 }
 
+// ExecutionError is the reason the execution of a transaction failed
+type ExecutionError struct {
+	// Error reported by the node, such as "Bad jump destination". May be
+	// empty if no reason is known
+	Description string
+}
+
+func (e *ExecutionError) Error() string {
+	if e.Description == "" {
+		return "Transaction execution failed"
+	}
+	return e.Description
+}
+
+// ReceiptStatus is the status recorded in a transaction receipt
+type ReceiptStatus int
+
+const (
+	// Receipts of transactions before the Byzantium fork have no status
+	ReceiptStatusUnknown ReceiptStatus = iota
+	ReceiptStatusFailed
+	ReceiptStatusSuccess
+)
+
+// Parses the receipt status of the API, "1", "0" or empty
+func parseReceiptStatus(s string) ReceiptStatus {
+	switch s {
+	case "1", "0x1":
+		return ReceiptStatusSuccess
+	case "0", "0x0":
+		return ReceiptStatusFailed
+	}
+	return ReceiptStatusUnknown
+}
+
+// Response with the execution status of a transaction
+type transactionStatusResponse struct {
+	*baseResponse
+	Result *struct {
+		IsError        string `json:"isError"`
+		ErrDescription string `json:"errDescription"`
+	} `json:"result"`
+}
+
+// Response with the receipt status of a transaction
+type receiptStatusResponse struct {
+	*baseResponse
+	Result *struct {
+		Status string `json:"status"`
+	} `json:"result"`
+}
+
+// Parses a getstatus response into the execution error, nil if the
+// transaction succeeded
+func parseTransactionStatusResponse(r io.Reader) (*ExecutionError, error) {
+	res := &transactionStatusResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+	if err := checkResponse(res.baseResponse); err != nil {
+		return nil, err
+	}
+	if res.Result == nil {
+		return nil, errors.New("result is empty")
+	}
+	if !parseBool(res.Result.IsError) {
+		return nil, nil
+	}
+	return &ExecutionError{Description: res.Result.ErrDescription}, nil
+}
+
+func parseReceiptStatusResponse(r io.Reader) (ReceiptStatus, error) {
+	res := &receiptStatusResponse{baseResponse: &baseResponse{}}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return ReceiptStatusUnknown, err
+	}
+	if err := checkResponse(res.baseResponse); err != nil {
+		return ReceiptStatusUnknown, err
+	}
+	if res.Result == nil {
+		return ReceiptStatusUnknown, errors.New("result is empty")
+	}
+	return parseReceiptStatus(res.Result.Status), nil
+}
+
 // Internal transaction is a value transfer inside a contract's code
 type InternalTransaction struct {
 	// Transaction type, such as "call" for a method call
 	Type    string
 	TraceID string
+
+	// Position of this call in the call tree, parsed from TraceID. For
+	// example [0 1] is the second call made by the first top level call
+	TraceAddress []int
+
+	// Raw error code reported for a failed call, such as "Reverted"
+	ErrCode string
+}
+
+// ParentTraceID returns the TraceID of the call that made this one, or an
+// empty string for a top level call
+func (t *InternalTransaction) ParentTraceID() string {
+	i := strings.LastIndex(t.TraceID, "_")
+	if i < 0 {
+		return ""
+	}
+	return t.TraceID[:i]
+}
+
+// Parses a trace ID such as "0_1_2" into its call tree positions
+func parseTraceAddress(traceID string) []int {
+	if traceID == "" {
+		return nil
+	}
+	parts := strings.Split(traceID, "_")
+	address := make([]int, len(parts))
+	for i, p := range parts {
+		address[i] = parseInt(p)
+	}
+	return address
 }
 
 func parseTransaction(tx *transactionResponse) *Transaction {
@@ -144,33 +335,67 @@ func parseTransaction(tx *transactionResponse) *Transaction {
 	// Internal transactions should always have a Type
 	if tx.Type != "" {
 		parsedTx.Internal = &InternalTransaction{
-			Type:    tx.Type,
-			TraceID: tx.TraceID,
+			Type:         tx.Type,
+			TraceID:      tx.TraceID,
+			TraceAddress: parseTraceAddress(tx.TraceID),
+			ErrCode:      tx.ErrCode,
 		}
 	}
 
-	if tx.ErrCode != "" {
-		parsedTx.Error = errors.New(tx.ErrCode)
+	if parsedTx.IsError || tx.ErrCode != "" {
+		parsedTx.Error = &ExecutionError{Description: tx.ErrCode}
 	}
 	return parsedTx
 }
 
-func parseTransactionsResponse(r io.Reader) ([]*Transaction, error) {
+// Message of a list response with no results
+const noTransactionsMessage = "No transactions found"
+
+// Decodes a list of transactions without parsing them, for transfer types
+// that extend Transaction with their own fields
+func decodeTransactionsResponse(r io.Reader) ([]*transactionResponse, error) {
 	res := &transactionsResponse{baseResponse: &baseResponse{}}
 	if err := json.NewDecoder(r).Decode(&res); err != nil {
 		return nil, err
 	}
-	transactions := make([]*Transaction, len(res.Transactions))
-	for i, tx := range res.Transactions {
+
+	var txs []*transactionResponse
+	if err := checkRawResponse(res.baseResponse, res.Result); err != nil {
+		// An empty list is reported with an error status
+		if res.Message == noTransactionsMessage && json.Unmarshal(res.Result, &txs) == nil && len(txs) == 0 {
+			return []*transactionResponse{}, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(res.Result, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+func parseTransactionsResponse(r io.Reader) ([]*Transaction, error) {
+	res, err := decodeTransactionsResponse(r)
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*Transaction, len(res))
+	for i, tx := range res {
 		transactions[i] = parseTransaction(tx)
 	}
 	return transactions, nil
 }
 
-func (c *Client) buildTransactionsRequest(addr string, page, offset int, category txType) (*http.Request, error) {
+func (c *Client) buildTransactionsRequest(addr string, options TransactionListOptions, category txType) (*http.Request, error) {
 	var action string
-	if page <= 0 {
-		return nil, errors.New("page param must >= 1")
+	if err := options.validate(category); err != nil {
+		return nil, err
+	}
+	// Internal transactions can also be listed by block range alone
+	if addr == "" && options.ContractAddress == "" && category != txInternal {
+		return nil, errors.New("Address is required")
+	}
+	if addr == "" && category == txInternal && options.EndBlock == 0 {
+		return nil, errors.New("Address or block range is required")
 	}
 	switch category {
 	case txNormal:
@@ -179,6 +404,10 @@ func (c *Client) buildTransactionsRequest(addr string, page, offset int, categor
 		action = "txlistinternal"
 	case txToken:
 		action = "tokentx"
+	case txNFT:
+		action = "tokennfttx"
+	case txERC1155:
+		action = "token1155tx"
 	}
 	if action == "" {
 		return nil, errors.New("Unsupported transaction category")
@@ -186,15 +415,31 @@ func (c *Client) buildTransactionsRequest(addr string, page, offset int, categor
 	params := url.Values{}
 	params.Set("module", "account")
 	params.Set("action", action)
-	params.Set("address", addr)
-	params.Set("sort", "desc") //newest transactions first
-	params.Set("page", fmt.Sprint(page))
-	params.Set("offset", fmt.Sprint(offset))
+	if addr != "" {
+		params.Set("address", addr)
+	}
+	if options.ContractAddress != "" {
+		params.Set("contractaddress", options.ContractAddress)
+	}
+	if options.StartBlock != 0 {
+		params.Set("startblock", strconv.Itoa(options.StartBlock))
+	}
+	if options.EndBlock != 0 {
+		params.Set("endblock", strconv.Itoa(options.EndBlock))
+	}
+	params.Set("sort", string(SortDesc)) //newest transactions first
+	if options.Sort != "" {
+		params.Set("sort", string(options.Sort))
+	}
+	if options.Page != 0 {
+		params.Set("page", fmt.Sprint(options.Page))
+		params.Set("offset", fmt.Sprint(options.Offset))
+	}
 	return c.buildRequest(params)
 }
 
-func (c *Client) transactions(ctx context.Context, addr string, page, offset int, category txType) ([]*Transaction, error) {
-	req, err := c.buildTransactionsRequest(addr, page, offset, category)
+func (c *Client) transactions(ctx context.Context, addr string, options TransactionListOptions, category txType) ([]*Transaction, error) {
+	req, err := c.buildTransactionsRequest(addr, options, category)
 	if err != nil {
 		return nil, err
 	}
@@ -206,37 +451,270 @@ func (c *Client) transactions(ctx context.Context, addr string, page, offset int
 	return parseTransactionsResponse(resp.Body)
 }
 
+// Same as transactions, but returns the unparsed list
+func (c *Client) transactionResponses(ctx context.Context, addr string, options TransactionListOptions, category txType) ([]*transactionResponse, error) {
+	req, err := c.buildTransactionsRequest(addr, options, category)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodeTransactionsResponse(resp.Body)
+}
+
+func (c *Client) buildInternalTransactionsByHashRequest(hash string) (*http.Request, error) {
+	if !strings.HasPrefix(hash, "0x") {
+		return nil, errors.New("Transaction hash must begin with 0x")
+	}
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "txlistinternal")
+	params.Set("txhash", hash)
+	return c.buildRequest(params)
+}
+
+func (c *Client) internalTransactionsByHash(ctx context.Context, hash string) ([]*Transaction, error) {
+	req, err := c.buildInternalTransactionsByHashRequest(hash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	txs, err := parseTransactionsResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Results of a hash query do not repeat the hash
+	for _, tx := range txs {
+		if tx.Hash == "" {
+			tx.Hash = hash
+		}
+	}
+	return txs, nil
+}
+
+func (c *Client) buildTransactionStatusRequest(hash string, action string) (*http.Request, error) {
+	if !strings.HasPrefix(hash, "0x") {
+		return nil, errors.New("Transaction hash must begin with 0x")
+	}
+	params := url.Values{}
+	params.Set("module", "transaction")
+	params.Set("action", action)
+	params.Set("txhash", hash)
+	return c.buildRequest(params)
+}
+
+func (c *Client) transactionStatus(ctx context.Context, hash string) (*ExecutionError, error) {
+	req, err := c.buildTransactionStatusRequest(hash, "getstatus")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseTransactionStatusResponse(resp.Body)
+}
+
+func (c *Client) transactionReceiptStatus(ctx context.Context, hash string) (ReceiptStatus, error) {
+	req, err := c.buildTransactionStatusRequest(hash, "gettxreceiptstatus")
+	if err != nil {
+		return ReceiptStatusUnknown, err
+	}
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return ReceiptStatusUnknown, err
+	}
+	defer resp.Body.Close()
+	return parseReceiptStatusResponse(resp.Body)
+}
+
+func (c *Client) updateTransactionStatus(ctx context.Context, tx *Transaction) error {
+	if tx == nil {
+		return errors.New("Transaction is nil")
+	}
+	execErr, err := c.transactionStatus(ctx, tx.Hash)
+	if err != nil {
+		return err
+	}
+	tx.IsError = execErr != nil
+	tx.Error = nil
+	if execErr != nil {
+		tx.Error = execErr
+	}
+	return nil
+}
+
+// Fetches a single page of transactions, newest first
+func (c *Client) transactionsPage(ctx context.Context, addr string, page, offset int, category txType) ([]*Transaction, error) {
+	if page <= 0 {
+		return nil, errors.New("page param must >= 1")
+	}
+	options := TransactionListOptions{
+		Sort:   SortDesc,
+		Page:   page,
+		Offset: offset,
+	}
+	return c.transactions(ctx, addr, options, category)
+}
+
 // Transactions returns a list of standard transactions to/from the given address
 func (c *Client) Transactions(addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(context.Background(), addr, page, offset, txNormal)
+	return c.transactionsPage(context.Background(), addr, page, offset, txNormal)
 }
 
 // TransactionsContext returns a list of standard transactions to/from the given address
 // with a custom context
 func (c *Client) TransactionsContext(ctx context.Context, addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(ctx, addr, page, offset, txNormal)
+	return c.transactionsPage(ctx, addr, page, offset, txNormal)
 }
 
 // TokenTransactions returns a list of ERC20 token transactions to/from the given address
 func (c *Client) TokenTransactions(addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(context.Background(), addr, page, offset, txToken)
+	return c.transactionsPage(context.Background(), addr, page, offset, txToken)
 }
 
 // TokenTransactionsContext returns a list of ERC20 token transactions to/from the given address
 // with a custom context
 func (c *Client) TokenTransactionsContext(ctx context.Context, addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(ctx, addr, page, offset, txToken)
+	return c.transactionsPage(ctx, addr, page, offset, txToken)
 }
 
 // InternalTransactions returns a list of internal contract transactions for
 // the contract at the given address
 func (c *Client) InternalTransactions(addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(context.Background(), addr, page, offset, txInternal)
+	return c.transactionsPage(context.Background(), addr, page, offset, txInternal)
 }
 
 // InternalTransactionsContext returns a list of internal contract transactions for
 // the contract at the given address
 // with a custom context
 func (c *Client) InternalTransactionsContext(ctx context.Context, addr string, page, offset int) ([]*Transaction, error) {
-	return c.transactions(ctx, addr, page, offset, txInternal)
+	return c.transactionsPage(ctx, addr, page, offset, txInternal)
+}
+
+func (c *Client) internalTransactionsByBlockRange(ctx context.Context, startBlock, endBlock, page, offset int) ([]*Transaction, error) {
+	if page <= 0 {
+		return nil, errors.New("page param must >= 1")
+	}
+	if endBlock <= 0 {
+		return nil, errors.New("End block must be >= 1")
+	}
+	options := TransactionListOptions{
+		StartBlock: startBlock,
+		EndBlock:   endBlock,
+		Sort:       SortDesc,
+		Page:       page,
+		Offset:     offset,
+	}
+	return c.transactions(ctx, "", options, txInternal)
+}
+
+// TransactionsWithOptions returns a list of standard transactions to/from the
+// given address, filtered by options
+func (c *Client) TransactionsWithOptions(addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(context.Background(), addr, options, txNormal)
+}
+
+// TransactionsWithOptionsContext returns a list of standard transactions
+// to/from the given address, filtered by options, with a custom context
+func (c *Client) TransactionsWithOptionsContext(ctx context.Context, addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(ctx, addr, options, txNormal)
+}
+
+// TokenTransactionsWithOptions returns a list of ERC20 token transactions
+// to/from the given address, filtered by options. The address may be empty
+// if options.ContractAddress is set
+func (c *Client) TokenTransactionsWithOptions(addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(context.Background(), addr, options, txToken)
+}
+
+// TokenTransactionsWithOptionsContext returns a list of ERC20 token
+// transactions to/from the given address, filtered by options, with a custom
+// context
+func (c *Client) TokenTransactionsWithOptionsContext(ctx context.Context, addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(ctx, addr, options, txToken)
+}
+
+// InternalTransactionsWithOptions returns a list of internal contract
+// transactions for the contract at the given address, filtered by options
+func (c *Client) InternalTransactionsWithOptions(addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(context.Background(), addr, options, txInternal)
+}
+
+// InternalTransactionsWithOptionsContext returns a list of internal contract
+// transactions for the contract at the given address, filtered by options,
+// with a custom context
+func (c *Client) InternalTransactionsWithOptionsContext(ctx context.Context, addr string, options TransactionListOptions) ([]*Transaction, error) {
+	return c.transactions(ctx, addr, options, txInternal)
+}
+
+// InternalTransactionsByHash returns the internal contract transactions made
+// by the transaction with the given hash
+func (c *Client) InternalTransactionsByHash(hash string) ([]*Transaction, error) {
+	return c.internalTransactionsByHash(context.Background(), hash)
+}
+
+// InternalTransactionsByHashContext returns the internal contract
+// transactions made by the transaction with the given hash with a custom
+// context
+func (c *Client) InternalTransactionsByHashContext(ctx context.Context, hash string) ([]*Transaction, error) {
+	return c.internalTransactionsByHash(ctx, hash)
+}
+
+// InternalTransactionsByBlockRange returns a page of the internal contract
+// transactions made between startBlock and endBlock, inclusive
+func (c *Client) InternalTransactionsByBlockRange(startBlock, endBlock, page, offset int) ([]*Transaction, error) {
+	return c.internalTransactionsByBlockRange(context.Background(), startBlock, endBlock, page, offset)
+}
+
+// InternalTransactionsByBlockRangeContext returns a page of the internal
+// contract transactions made between startBlock and endBlock, inclusive,
+// with a custom context
+func (c *Client) InternalTransactionsByBlockRangeContext(ctx context.Context, startBlock, endBlock, page, offset int) ([]*Transaction, error) {
+	return c.internalTransactionsByBlockRange(ctx, startBlock, endBlock, page, offset)
+}
+
+// TransactionStatus returns the reason the execution of the transaction with
+// the given hash failed, or nil if it succeeded
+func (c *Client) TransactionStatus(hash string) (*ExecutionError, error) {
+	return c.transactionStatus(context.Background(), hash)
+}
+
+// TransactionStatusContext returns the reason the execution of the
+// transaction with the given hash failed, or nil if it succeeded, with a
+// custom context
+func (c *Client) TransactionStatusContext(ctx context.Context, hash string) (*ExecutionError, error) {
+	return c.transactionStatus(ctx, hash)
+}
+
+// TransactionReceiptStatus returns the receipt status of the transaction with
+// the given hash
+func (c *Client) TransactionReceiptStatus(hash string) (ReceiptStatus, error) {
+	return c.transactionReceiptStatus(context.Background(), hash)
+}
+
+// TransactionReceiptStatusContext returns the receipt status of the
+// transaction with the given hash with a custom context
+func (c *Client) TransactionReceiptStatusContext(ctx context.Context, hash string) (ReceiptStatus, error) {
+	return c.transactionReceiptStatus(ctx, hash)
+}
+
+// UpdateTransactionStatus fetches the execution status of the transaction
+// and sets its IsError and Error fields, as list results do
+func (c *Client) UpdateTransactionStatus(tx *Transaction) error {
+	return c.updateTransactionStatus(context.Background(), tx)
+}
+
+// UpdateTransactionStatusContext fetches the execution status of the
+// transaction and sets its IsError and Error fields with a custom context
+func (c *Client) UpdateTransactionStatusContext(ctx context.Context, tx *Transaction) error {
+	return c.updateTransactionStatus(ctx, tx)
 }
@@ -0,0 +1,189 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Number of transactions fetched per request when no Offset is given
+const defaultIteratorOffset = 1000
+
+// TransactionIterator walks every page of a transaction list. When the
+// result window of the API is exhausted, the query is restarted from the
+// block of the last transaction and transactions already returned from that
+// block are skipped.
+//
+//	it := client.NewTransactionIterator(address, TransactionListOptions{Sort: SortAsc})
+//	for it.Next(ctx) {
+//		tx := it.Tx()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TransactionIterator struct {
+	client   *Client
+	addr     string
+	category txType
+	options  TransactionListOptions
+
+	// Current page and position in it
+	page []*Transaction
+	pos  int
+	// No more pages to fetch
+	done bool
+
+	tx  *Transaction
+	err error
+
+	// Block of the last returned transaction and how many times each
+	// transaction was returned from it
+	lastBlock int
+	returned  map[string]int
+	// Block the current window starts from and the transactions of that
+	// block that were already returned by the previous window
+	windowBlock int
+	skip        map[string]int
+}
+
+func (c *Client) newTransactionIterator(addr string, options TransactionListOptions, category txType) *TransactionIterator {
+	if options.Sort == "" {
+		options.Sort = SortDesc
+	}
+	if options.Page == 0 {
+		options.Page = 1
+	}
+	if options.Offset == 0 {
+		options.Offset = defaultIteratorOffset
+	}
+	return &TransactionIterator{
+		client:      c,
+		addr:        addr,
+		category:    category,
+		options:     options,
+		lastBlock:   -1,
+		windowBlock: -1,
+	}
+}
+
+// NewTransactionIterator returns an iterator over all standard transactions
+// to/from the given address
+func (c *Client) NewTransactionIterator(addr string, options TransactionListOptions) *TransactionIterator {
+	return c.newTransactionIterator(addr, options, txNormal)
+}
+
+// NewTokenTransactionIterator returns an iterator over all ERC20 token
+// transactions to/from the given address
+func (c *Client) NewTokenTransactionIterator(addr string, options TransactionListOptions) *TransactionIterator {
+	return c.newTransactionIterator(addr, options, txToken)
+}
+
+// NewInternalTransactionIterator returns an iterator over all internal
+// contract transactions for the contract at the given address
+func (c *Client) NewInternalTransactionIterator(addr string, options TransactionListOptions) *TransactionIterator {
+	return c.newTransactionIterator(addr, options, txInternal)
+}
+
+// Next advances the iterator to the next transaction, fetching pages as
+// needed. It returns false when all transactions were read or on error
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	for it.err == nil {
+		for it.pos < len(it.page) {
+			tx := it.page[it.pos]
+			it.pos++
+			if it.duplicate(tx) {
+				continue
+			}
+			it.tx = tx
+			return true
+		}
+		if it.done {
+			return false
+		}
+		it.fetch(ctx)
+	}
+	return false
+}
+
+// Tx returns the current transaction
+func (it *TransactionIterator) Tx() *Transaction {
+	return it.tx
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+// Fetches the next page, sliding the window first if it is exhausted
+func (it *TransactionIterator) fetch(ctx context.Context) {
+	if it.options.Page*it.options.Offset > resultWindow {
+		if err := it.slide(); err != nil {
+			it.err = err
+			return
+		}
+	}
+	txs, err := it.client.transactions(ctx, it.addr, it.options, it.category)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.page, it.pos = txs, 0
+	it.options.Page++
+	if len(txs) < it.options.Offset {
+		it.done = true
+	}
+}
+
+// Restarts the query from the block of the last returned transaction
+func (it *TransactionIterator) slide() error {
+	if it.tx == nil || it.lastBlock < 0 {
+		return errors.New("Result window exhausted without any transactions")
+	}
+	if it.lastBlock == it.windowBlock {
+		return fmt.Errorf("More than %d transactions in block %d", resultWindow, it.lastBlock)
+	}
+	if it.options.Sort == SortAsc {
+		it.options.StartBlock = it.lastBlock
+	} else {
+		it.options.EndBlock = it.lastBlock
+	}
+	it.options.Page = 1
+	it.windowBlock = it.lastBlock
+	it.skip = make(map[string]int, len(it.returned))
+	for key, n := range it.returned {
+		it.skip[key] = n
+	}
+	return nil
+}
+
+// Reports whether the transaction was already returned before the window
+// slid, and records it otherwise
+func (it *TransactionIterator) duplicate(tx *Transaction) bool {
+	block := -1
+	if tx.Block != nil {
+		block = tx.Block.Number
+	}
+	key := transactionKey(tx)
+	if block == it.windowBlock && it.skip[key] > 0 {
+		it.skip[key]--
+		return true
+	}
+	if block != it.lastBlock {
+		it.lastBlock = block
+		it.returned = make(map[string]int)
+	}
+	it.returned[key]++
+	return false
+}
+
+// Identifies a transaction within its block. Internal and token
+// transactions share the hash of their parent transaction
+func transactionKey(tx *Transaction) string {
+	key := fmt.Sprintf("%s/%s/%s/%s/%s", tx.Hash, tx.From, tx.To, tx.Value, tx.ContractAddress)
+	if tx.Internal != nil {
+		key += "/" + tx.Internal.TraceID
+	}
+	return key
+}
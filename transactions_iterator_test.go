@@ -0,0 +1,182 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves a transaction list for the given blocks, filtered, sorted and
+// paginated like the API. Transactions are numbered in ascending order
+func fakeTransactionList(blocks []int) *fakeTransport {
+	return &fakeTransport{handle: func(req *http.Request) (string, error) {
+		q := req.URL.Query()
+		start, _ := strconv.Atoi(q.Get("startblock"))
+		end, err := strconv.Atoi(q.Get("endblock"))
+		if err != nil {
+			end = int(^uint(0) >> 1)
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		var result []*transactionResponse
+		for i, block := range blocks {
+			if block < start || block > end {
+				continue
+			}
+			result = append(result, &transactionResponse{
+				BlockNumber: strconv.Itoa(block),
+				Hash:        "0x" + strconv.Itoa(i),
+				Value:       "0",
+			})
+		}
+		if q.Get("sort") == string(SortDesc) {
+			for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+				result[i], result[j] = result[j], result[i]
+			}
+		}
+		from := (page - 1) * offset
+		if from > len(result) {
+			from = len(result)
+		}
+		to := from + offset
+		if to > len(result) {
+			to = len(result)
+		}
+
+		status, message := "1", "OK"
+		if from == to {
+			// Empty pages are reported with an error status
+			status, message = "0", noTransactionsMessage
+		}
+		body, err := json.Marshal(map[string]interface{}{
+			"status":  status,
+			"message": message,
+			"result":  result[from:to],
+		})
+		return string(body), err
+	}}
+}
+
+// Collects the hashes returned by the iterator
+func iterateHashes(it *TransactionIterator) []string {
+	var hashes []string
+	for it.Next(context.Background()) {
+		hashes = append(hashes, it.Tx().Hash)
+	}
+	return hashes
+}
+
+func TestTransactionIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(window int) { resultWindow = window }(resultWindow)
+	resultWindow = 4
+
+	c := newFakeClient(fakeTransactionList([]int{1, 2, 2, 2, 3}))
+	it := c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+		Sort:   SortAsc,
+		Offset: 2,
+	})
+	hashes := iterateHashes(it)
+	assert.NoError(it.Err())
+	assert.Equal([]string{"0x0", "0x1", "0x2", "0x3", "0x4"}, hashes)
+}
+
+func TestTransactionIteratorDesc(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(window int) { resultWindow = window }(resultWindow)
+	resultWindow = 4
+
+	// Slides twice, moving the end block down
+	c := newFakeClient(fakeTransactionList([]int{1, 2, 2, 2, 3}))
+	it := c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+		Sort:   SortDesc,
+		Offset: 2,
+	})
+	hashes := iterateHashes(it)
+	assert.NoError(it.Err())
+	assert.Equal([]string{"0x4", "0x3", "0x2", "0x1", "0x0"}, hashes)
+}
+
+func TestTransactionIteratorBlockRange(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(window int) { resultWindow = window }(resultWindow)
+	resultWindow = 4
+
+	// The end block is kept when sliding the start block
+	c := newFakeClient(fakeTransactionList([]int{1, 2, 2, 3, 4, 5}))
+	it := c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+		EndBlock: 4,
+		Sort:     SortAsc,
+		Offset:   2,
+	})
+	hashes := iterateHashes(it)
+	assert.NoError(it.Err())
+	assert.Equal([]string{"0x0", "0x1", "0x2", "0x3", "0x4"}, hashes)
+
+	// The start block is kept when sliding the end block
+	c = newFakeClient(fakeTransactionList([]int{1, 2, 2, 3, 4, 5}))
+	it = c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+		StartBlock: 2,
+		Sort:       SortDesc,
+		Offset:     2,
+	})
+	hashes = iterateHashes(it)
+	assert.NoError(it.Err())
+	assert.Equal([]string{"0x5", "0x4", "0x3", "0x2", "0x1"}, hashes)
+}
+
+func TestTransactionIteratorFullBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(window int) { resultWindow = window }(resultWindow)
+	resultWindow = 4
+
+	c := newFakeClient(fakeTransactionList([]int{1, 2, 2, 2, 2, 2}))
+	it := c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+		Sort:   SortAsc,
+		Offset: 2,
+	})
+	hashes := iterateHashes(it)
+	assert.EqualError(it.Err(), "More than 4 transactions in block 2")
+	assert.Equal([]string{"0x0", "0x1", "0x2", "0x3", "0x4"}, hashes)
+}
+
+func TestTransactionIteratorAPIError(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(window int) { resultWindow = window }(resultWindow)
+	resultWindow = 4
+
+	// Fails on the second page
+	for body, msg := range map[string]string{
+		`{"status":"0","message":"Query Timeout occured. Please select a smaller result dataset","result":null}`: "API Error: Query Timeout occured. Please select a smaller result dataset",
+		`{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`:                                     "API Error: Max rate limit reached",
+	} {
+		list := fakeTransactionList([]int{1, 2, 3, 4})
+		serve := list.handle
+		requests := 0
+		list.handle = func(req *http.Request) (string, error) {
+			requests++
+			if requests > 1 {
+				return body, nil
+			}
+			return serve(req)
+		}
+		c := newFakeClient(list)
+		it := c.NewTransactionIterator("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c", TransactionListOptions{
+			Sort:   SortAsc,
+			Offset: 2,
+		})
+		hashes := iterateHashes(it)
+		assert.EqualError(it.Err(), msg)
+		assert.Equal([]string{"0x0", "0x1"}, hashes)
+	}
+}
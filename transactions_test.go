@@ -3,6 +3,7 @@ package etherscan
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,6 +36,15 @@ func TestTransactions(t *testing.T) {
 	assert.EqualValues(big.NewInt(20000000000), tx.GasPrice)
 	assert.Equal(false, tx.IsError)
 	assert.EqualValues(4018297, tx.Confirmations)
+
+	txs, err = parseTransactionsResponse(strings.NewReader(`{"status":"0","message":"No transactions found","result":[]}`))
+	assert.NoError(err)
+	assert.Empty(txs)
+
+	_, err = parseTransactionsResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+	assert.EqualError(err, "API Error: Invalid API Key")
+	_, err = parseTransactionsResponse(strings.NewReader(`{"status":"0","message":"NOTOK","result":null}`))
+	assert.EqualError(err, "API Error: NOTOK")
 }
 
 func ExampleClient_Transactions() {
@@ -68,3 +78,107 @@ func ExampleClient_Transactions() {
 
 	`, tx.Hash, tx.Block.Number, tx.Timestamp, tx.From, tx.To, tx.Value, tx.Confirmations)
 }
+
+func TestBuildTransactionsRequest(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{}
+	addr := "0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c"
+
+	req, err := c.buildTransactionsRequest(addr, TransactionListOptions{
+		StartBlock: 100,
+		EndBlock:   200,
+		Sort:       SortAsc,
+		Page:       2,
+		Offset:     50,
+	}, txNormal)
+	assert.NoError(err)
+	q := req.URL.Query()
+	assert.Equal("txlist", q.Get("action"))
+	assert.Equal("100", q.Get("startblock"))
+	assert.Equal("200", q.Get("endblock"))
+	assert.Equal("asc", q.Get("sort"))
+	assert.Equal("2", q.Get("page"))
+	assert.Equal("50", q.Get("offset"))
+
+	req, err = c.buildTransactionsRequest("", TransactionListOptions{
+		ContractAddress: "0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2",
+	}, txToken)
+	assert.NoError(err)
+	q = req.URL.Query()
+	assert.Equal("0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2", q.Get("contractaddress"))
+	assert.Equal("desc", q.Get("sort"))
+	assert.Empty(q.Get("address"))
+	assert.Empty(q.Get("page"))
+
+	invalid := []TransactionListOptions{
+		{StartBlock: 200, EndBlock: 100},
+		{Sort: "up"},
+		{Page: -1},
+		{Offset: 10},
+		{ContractAddress: "0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2"},
+	}
+	for _, options := range invalid {
+		_, err = c.buildTransactionsRequest(addr, options, txNormal)
+		assert.Error(err)
+	}
+}
+
+func TestInternalTransactions(t *testing.T) {
+	assert := assert.New(t)
+	r := loadTestData(t, "transactions_internal_hash.json")
+
+	txs, err := parseTransactionsResponse(r)
+	assert.NoError(err)
+	assert.Len(txs, 2)
+
+	tx := txs[0]
+	assert.NotNil(tx.Internal)
+	assert.Equal("call", tx.Internal.Type)
+	assert.False(tx.IsError)
+	assert.NoError(tx.Error)
+
+	tx = txs[1]
+	assert.True(tx.IsError)
+	assert.Equal("Out of gas", tx.Internal.ErrCode)
+	assert.EqualError(tx.Error, "Out of gas")
+	assert.IsType(&ExecutionError{}, tx.Error)
+	assert.Equal([]int{0, 1}, tx.Internal.TraceAddress)
+	assert.Equal("0", tx.Internal.ParentTraceID())
+}
+
+func TestBuildInternalTransactionsRequest(t *testing.T) {
+	assert := assert.New(t)
+	c := &Client{}
+
+	req, err := c.buildInternalTransactionsByHashRequest("0x40eb908387324f2b575b4879cd9d7188f69c8fc9d87c901b9e2daaea4b442170")
+	assert.NoError(err)
+	assert.Equal("0x40eb908387324f2b575b4879cd9d7188f69c8fc9d87c901b9e2daaea4b442170", req.URL.Query().Get("txhash"))
+	assert.Empty(req.URL.Query().Get("address"))
+
+	req, err = c.buildTransactionsRequest("", TransactionListOptions{StartBlock: 13481773, EndBlock: 13491773}, txInternal)
+	assert.NoError(err)
+	assert.Equal("13491773", req.URL.Query().Get("endblock"))
+
+	_, err = c.buildTransactionsRequest("", TransactionListOptions{}, txInternal)
+	assert.Error(err)
+}
+
+func TestTransactionStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	execErr, err := parseTransactionStatusResponse(loadTestData(t, "transaction_status.json"))
+	assert.NoError(err)
+	assert.EqualError(execErr, "Bad jump destination")
+
+	execErr, err = parseTransactionStatusResponse(strings.NewReader(`{"status":"1","message":"OK","result":{"isError":"0","errDescription":""}}`))
+	assert.NoError(err)
+	assert.Nil(execErr)
+
+	status, err := parseReceiptStatusResponse(loadTestData(t, "transaction_receipt_status.json"))
+	assert.NoError(err)
+	assert.Equal(ReceiptStatusSuccess, status)
+
+	status, err = parseReceiptStatusResponse(strings.NewReader(`{"status":"1","message":"OK","result":{"status":""}}`))
+	assert.NoError(err)
+	assert.Equal(ReceiptStatusUnknown, status)
+}